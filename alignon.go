@@ -0,0 +1,119 @@
+package paragraph
+
+import (
+	"regexp"
+	"strings"
+)
+
+// AlignOnSettings{Delimiter: "=", Fields: 2, CommentPrefix: "#"}
+type AlignOnSettings struct {
+	Delimiter         string         // the string between the fields, such as "=" or ":"
+	Pattern           *regexp.Regexp // the delimiters when set, instead of Delimiter, such as regexp.MustCompile(`\s+`)
+	Separator         string         // written between the fields, "" for the delimiter surrounded by spaces
+	Fields            int            // maximum number of fields, the last one holding the rest of the line, 0 for no limit
+	RightAlignNumbers bool           // the columns holding only numbers are aligned to the right
+	CommentPrefix     string         // the lines starting with it, after their indentation, are kept unchanged
+}
+
+// AlignOn aligns the lines of the Paragraph slice on their delimiters, like the column -t command does.
+// Each line is split into fields, which are trimmed and padded to the width of their column.
+// The fields are joined with the separator, or with the delimiter surrounded by single spaces,
+// a delimiter made of spaces being replaced by a single space.
+// The indentation of the lines is kept in their first field, and the blank lines, the comment lines
+// and the lines without delimiter are kept unchanged.
+// - settings holds the delimiter, the separator and the options.
+func (linesIn Paragraph) AlignOn(settings AlignOnSettings) (linesOut Paragraph) {
+	if settings.Delimiter == "" && settings.Pattern == nil {
+		return linesIn
+	}
+	l := len(linesIn)
+	fields := make([][]string, l)
+	separators := make([][]string, l)
+	var widths []int
+	var numbers []bool
+	for i, s := range linesIn {
+		if isBlank(s) || (settings.CommentPrefix != "" && strings.HasPrefix(strings.TrimLeft(s, " \t"), settings.CommentPrefix)) {
+			continue
+		}
+		fields[i], separators[i] = splitFields(s, settings)
+		if len(fields[i]) < 2 {
+			fields[i] = nil
+			continue
+		}
+		for k, field := range fields[i] {
+			if k == len(widths) {
+				widths = append(widths, 0)
+				numbers = append(numbers, true)
+			}
+			widths[k] = maxint(widths[k], DisplayWidth(field))
+			numbers[k] = numbers[k] && (field == "" || isNumber(field))
+		}
+	}
+	linesOut = NewWithGivenLen(l)
+	var sb strings.Builder
+	for i, s := range linesIn {
+		if fields[i] == nil {
+			linesOut[i] = s
+			continue
+		}
+		sb.Reset()
+		for k, field := range fields[i] {
+			if k > 0 {
+				sb.WriteString(separators[i][k-1])
+			}
+			switch {
+			case settings.RightAlignNumbers && numbers[k]:
+				sb.WriteString(strings.Repeat(" ", widths[k]-DisplayWidth(field)) + field)
+			case k == len(fields[i])-1: // no trailing spaces
+				sb.WriteString(field)
+			default:
+				sb.WriteString(padRight(field, " ", widths[k], DisplayWidth))
+			}
+		}
+		linesOut[i] = sb.String()
+	}
+	return
+}
+
+// splitFields splits a line into trimmed fields, and returns them with the separators to write between them.
+func splitFields(s string, settings AlignOnSettings) (fields []string, separators []string) {
+	s = strings.TrimRight(s, " \t")
+	var bounds [][]int
+	if settings.Pattern != nil {
+		bounds = settings.Pattern.FindAllStringIndex(s, -1)
+	} else {
+		for i, start := strings.Index(s, settings.Delimiter), 0; i >= 0; i = strings.Index(s[start:], settings.Delimiter) {
+			bounds = append(bounds, []int{start + i, start + i + len(settings.Delimiter)})
+			start += i + len(settings.Delimiter)
+		}
+	}
+	indentation := len(s) - len(strings.TrimLeft(s, " \t"))
+	start := 0
+	for _, b := range bounds {
+		if b[1] <= indentation || b[0] == b[1] { // the indentation and the empty matches are not delimiters
+			continue
+		}
+		if settings.Fields > 0 && len(fields) == settings.Fields-1 {
+			break
+		}
+		fields = append(fields, s[start:b[0]])
+		separator := settings.Separator
+		if separator == "" {
+			separator = " "
+			if d := strings.TrimSpace(s[b[0]:b[1]]); d != "" {
+				separator = " " + d + " "
+			}
+		}
+		separators = append(separators, separator)
+		start = b[1]
+	}
+	fields = append(fields, s[start:])
+	for k := range fields {
+		if k == 0 {
+			fields[k] = strings.TrimRight(fields[k], " \t")
+		} else {
+			fields[k] = strings.TrimSpace(fields[k])
+		}
+	}
+	return
+}
@@ -0,0 +1,221 @@
+package paragraph
+
+import (
+	"strconv"
+	"strings"
+)
+
+const (
+	escapeChar = '\x1b'
+	sgrReset   = "\x1b[0m"
+)
+
+// nextToken returns the first token of s and the rest of the string.
+// A token is either a terminal escape sequence, which has no width, or a grapheme cluster.
+func nextToken(s string) (token string, escape bool, rest string) {
+	if n := escapeLength(s); n > 0 {
+		return s[:n], true, s[n:]
+	}
+	token, rest = nextGrapheme(s)
+	return token, false, rest
+}
+
+// escapeLength returns the length in bytes of the escape sequence starting s, or 0 if s doesn't start with one.
+// CSI sequences (ESC [ ... final byte), OSC sequences (ESC ] ... BEL or ESC \) and
+// two-byte escape sequences are recognized.
+func escapeLength(s string) int {
+	if len(s) < 2 || s[0] != escapeChar {
+		return 0
+	}
+	switch s[1] {
+	case '[': // CSI: parameter bytes, intermediate bytes, final byte
+		for i := 2; i < len(s); i++ {
+			if s[i] >= 0x40 && s[i] <= 0x7E {
+				return i + 1
+			}
+			if s[i] < 0x20 || s[i] > 0x3F {
+				return 0
+			}
+		}
+		return 0
+	case ']': // OSC: terminated by BEL or ST
+		for i := 2; i < len(s); i++ {
+			if s[i] == '\a' {
+				return i + 1
+			}
+			if s[i] == escapeChar && i+1 < len(s) && s[i+1] == '\\' {
+				return i + 2
+			}
+		}
+		return 0
+	}
+	if s[1] >= 0x40 && s[1] <= 0x5F {
+		return 2
+	}
+	return 0
+}
+
+// StripANSI returns s without any of its terminal escape sequences.
+func StripANSI(s string) string {
+	if strings.IndexByte(s, escapeChar) < 0 {
+		return s
+	}
+	var sb strings.Builder
+	for s != "" {
+		token, escape, rest := nextToken(s)
+		if !escape {
+			sb.WriteString(token)
+		}
+		s = rest
+	}
+	return sb.String()
+}
+
+// StripANSI returns a copy of the Paragraph slice without any terminal escape sequences.
+func (linesIn Paragraph) StripANSI() (linesOut Paragraph) {
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		linesOut[i] = StripANSI(linesIn[i])
+	}
+	return
+}
+
+// activeSGR returns the SGR (Select Graphic Rendition) sequence restoring the style still active at the end of s,
+// or "" when the style is the default one.
+func activeSGR(s string) string {
+	var state sgrState
+	for i := strings.IndexByte(s, escapeChar); i >= 0 && s != ""; i = strings.IndexByte(s, escapeChar) {
+		s = s[i:]
+		n := escapeLength(s)
+		if n == 0 {
+			s = s[1:]
+			continue
+		}
+		if isSGR(s[:n]) {
+			state.apply(s[:n])
+		}
+		s = s[n:]
+	}
+	return state.sequence()
+}
+
+// sgrState is the graphic rendition set by SGR sequences: the attributes, such as bold (1) or reverse (7),
+// and the foreground, background and underline colors, kept as their SGR parameters.
+type sgrState struct {
+	attributes uint16 // bit n is set for the attribute n, from 1 to 9
+	foreground string
+	background string
+	underline  string
+}
+
+// isSGR tells whether the escape sequence seq is an SGR sequence.
+func isSGR(seq string) bool {
+	return len(seq) >= 3 && seq[1] == '[' && seq[len(seq)-1] == 'm'
+}
+
+// apply updates the state with the parameters of the SGR sequence seq,
+// and tells whether one of them resets an attribute or a color, fully or partially.
+func (state *sgrState) apply(seq string) (reset bool) {
+	params := strings.Split(seq[2:len(seq)-1], ";")
+	for i := 0; i < len(params); i++ {
+		n, err := strconv.Atoi(params[i])
+		if params[i] == "" {
+			n, err = 0, nil
+		}
+		if err != nil {
+			continue
+		}
+		switch {
+		case n == 0:
+			*state, reset = sgrState{}, true
+		case n >= 1 && n <= 9:
+			state.attributes |= 1 << n
+		case n == 22: // neither bold nor faint
+			state.attributes &^= 1<<1 | 1<<2
+			reset = true
+		case n == 25: // not blinking, slowly or rapidly
+			state.attributes &^= 1<<5 | 1<<6
+			reset = true
+		case n >= 23 && n <= 29 && n != 26:
+			state.attributes &^= 1 << (n - 20)
+			reset = true
+		case n >= 30 && n <= 37 || n >= 90 && n <= 97:
+			state.foreground = params[i]
+		case n >= 40 && n <= 47 || n >= 100 && n <= 107:
+			state.background = params[i]
+		case n == 39:
+			state.foreground, reset = "", true
+		case n == 49:
+			state.background, reset = "", true
+		case n == 59:
+			state.underline, reset = "", true
+		case n == 38 || n == 48 || n == 58: // extended colors, 5;index or 2;r;g;b
+			end := i + 1
+			if end < len(params) && params[end] == "5" {
+				end += 2
+			} else if end < len(params) && params[end] == "2" {
+				end += 4
+			}
+			end = minint(end, len(params))
+			color := strings.Join(params[i:end], ";")
+			switch n {
+			case 38:
+				state.foreground = color
+			case 48:
+				state.background = color
+			default:
+				state.underline = color
+			}
+			i = end - 1
+		}
+	}
+	return
+}
+
+// merge returns the state of the text styled by outer, in which inner sets its own attributes and colors.
+func (outer sgrState) merge(inner sgrState) sgrState {
+	outer.attributes |= inner.attributes
+	if inner.foreground != "" {
+		outer.foreground = inner.foreground
+	}
+	if inner.background != "" {
+		outer.background = inner.background
+	}
+	if inner.underline != "" {
+		outer.underline = inner.underline
+	}
+	return outer
+}
+
+// sequence returns the SGR sequence setting the state from the default one, or "" for the default state.
+func (state sgrState) sequence() string {
+	params := make([]string, 0, 12)
+	for n := 1; n <= 9; n++ {
+		if state.attributes&(1<<n) != 0 {
+			params = append(params, strconv.Itoa(n))
+		}
+	}
+	for _, color := range []string{state.foreground, state.background, state.underline} {
+		if color != "" {
+			params = append(params, color)
+		}
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// carryStyle closes the style still active at the end of first and reopens it at the beginning of second,
+// so that the colors don't bleed when the two parts are displayed on different lines.
+func carryStyle(first string, second string) (string, string) {
+	active := activeSGR(first)
+	if active == "" {
+		return first, second
+	}
+	if second == "" {
+		return first + sgrReset, second
+	}
+	return first + sgrReset, active + second
+}
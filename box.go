@@ -1,7 +1,7 @@
 package paragraph
 
 import (
-	"github.com/tpfeiffer67/runesstr"
+	"strings"
 )
 
 type BoxPattern struct {
@@ -48,6 +48,8 @@ var boxPatterns = [BoxStyleCount]BoxPattern{
 	{`╱`, "▔", "╲", "│", "│", "╲", "▁", `╱`},
 	{"▁▂▃", "▃", "▃▂▁", "▌", "▐", "▜▃▂▁", "▁", "▁▂▃▛"},
 	{"", "▁▂▃▂", "", "█", "█", "█", "▃▂▁▂", "█"},
+
+	{"+", "-", "+", "|", "|", "+", "-", "+"},
 }
 
 func GetBoxPattern(style BoxStyle) BoxPattern {
@@ -72,30 +74,62 @@ func (linesIn Paragraph) AutoBox(settings BoxSettings, pattern BoxPattern) Parag
 	return linesIn.PadRight(" ", w).Box(settings, pattern)
 }
 
+// BoxHeight draws a box around the Paragraph slice like Box does, holding a given number of lines,
+// so that panels of equal size can be tiled.
+// The Paragraph is padded with blank lines as wide as its lines, or truncated with the overflow marker padded to the same width.
+// - settings holds the width and the labels of the box.
+// - height holds the number of lines inside the box, their vertical alignment and the overflow marker.
+// - pattern holds the glyphs of the box.
+func (linesIn Paragraph) BoxHeight(settings BoxSettings, height HeightSettings, pattern BoxPattern) Paragraph {
+	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth || pattern == boxPatterns[BoxStyleNone] {
+		return linesIn
+	}
+	w := linesIn.Width()
+	if len(linesIn) == 0 {
+		w = settings.Width
+	}
+	if height.OverflowMarker != "" {
+		height.OverflowMarker = padRight(height.OverflowMarker, " ", w, DisplayWidth)
+	}
+	return height.fit(linesIn, strings.Repeat(" ", w)).Box(settings, pattern)
+}
+
+// AutoBoxHeight draws a box around the Paragraph slice like AutoBox does, holding a given number of lines.
+// The overflow marker and the blank lines are padded to the width of the box like the other lines.
+// - settings holds the labels of the box.
+// - height holds the number of lines inside the box, their vertical alignment and the overflow marker.
+// - pattern holds the glyphs of the box.
+func (linesIn Paragraph) AutoBoxHeight(settings BoxSettings, height HeightSettings, pattern BoxPattern) Paragraph {
+	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth || pattern == boxPatterns[BoxStyleNone] {
+		return linesIn
+	}
+	return height.fit(linesIn, "").AutoBox(settings, pattern)
+}
+
 func (linesIn Paragraph) Box(settings BoxSettings, pattern BoxPattern) (linesOut Paragraph) {
 	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth || pattern == boxPatterns[BoxStyleNone] {
 		return linesIn
 	}
 	width := settings.Width
-	bordersWidth := runesstr.Length(pattern.LeftBorder) + runesstr.Length(pattern.RightBorder)
-	toplabel, ltopleft, ltopright := processLabel(settings.TopLabel, settings.TopLabelAlign, width, bordersWidth, runesstr.Length(pattern.TopLeftCorner)+runesstr.Length(pattern.TopRightCorner))
-	bottomlabel, lbottomleft, lbottomright := processLabel(settings.BottomLabel, settings.BottomLabelAlign, width, bordersWidth, runesstr.Length(pattern.BottomLeftCorner)+runesstr.Length(pattern.BottomRightCorner))
+	bordersWidth := DisplayWidth(pattern.LeftBorder) + DisplayWidth(pattern.RightBorder)
+	toplabel, ltopleft, ltopright := processLabel(settings.TopLabel, settings.TopLabelAlign, width, bordersWidth, DisplayWidth(pattern.TopLeftCorner)+DisplayWidth(pattern.TopRightCorner))
+	bottomlabel, lbottomleft, lbottomright := processLabel(settings.BottomLabel, settings.BottomLabelAlign, width, bordersWidth, DisplayWidth(pattern.BottomLeftCorner)+DisplayWidth(pattern.BottomRightCorner))
 
 	l := len(linesIn)
 	linesOut = NewWithGivenLen(l + 2)
-	linesOut[0] = pattern.TopLeftCorner + runesstr.PadRight("", pattern.TopBorder, ltopleft) + toplabel + runesstr.PadRight("", pattern.TopBorder, ltopright) + pattern.TopRightCorner
+	linesOut[0] = pattern.TopLeftCorner + padRight("", pattern.TopBorder, ltopleft, DisplayWidth) + toplabel + padRight("", pattern.TopBorder, ltopright, DisplayWidth) + pattern.TopRightCorner
 	for i := 0; i < l; i++ {
 		linesOut[i+1] = pattern.LeftBorder + linesIn[i] + pattern.RightBorder
 	}
-	linesOut[l+1] = pattern.BottomLeftCorner + runesstr.PadRight("", pattern.BottomBorder, lbottomleft) + bottomlabel + runesstr.PadRight("", pattern.BottomBorder, lbottomright) + pattern.BottomRightCorner
+	linesOut[l+1] = pattern.BottomLeftCorner + padRight("", pattern.BottomBorder, lbottomleft, DisplayWidth) + bottomlabel + padRight("", pattern.BottomBorder, lbottomright, DisplayWidth) + pattern.BottomRightCorner
 	return
 }
 
 func processLabel(label string, align LabelAlign, width int, bordersWidth int, cornersWidth int) (rLabel string, lleft int, lright int) {
-	l := width + bordersWidth - runesstr.Length(label) - cornersWidth
+	l := width + bordersWidth - DisplayWidth(label) - cornersWidth
 	if l <= 0 {
 		l = 0
-		rLabel = runesstr.Left(label, width)
+		rLabel = cut(label, width, DisplayWidth)
 	} else {
 		rLabel = label
 	}
@@ -0,0 +1,177 @@
+package paragraph
+
+import (
+	"sort"
+	"strings"
+)
+
+// Canvas is a surface of fixed width and height on which Paragraph slices are drawn at given coordinates,
+// like the panels of a terminal dashboard. The drawings are composited by increasing z-order
+// when the Canvas is exported with its Paragraph method.
+type Canvas struct {
+	width    int
+	height   int
+	drawings []drawing
+}
+
+// DrawSettings{X: 2, Y: 1, Z: 1, Transparent: " "}
+type DrawSettings struct {
+	X           int    // column of the left side, can be negative
+	Y           int    // row of the top side, can be negative
+	Z           int    // the drawings of higher z-order are drawn over the others, the later ones over the earlier ones for the same z-order
+	Transparent string // the characters through which the drawings below are seen, such as " "
+	MergeLines  bool   // the overlapping box-drawing characters are joined, such as "┐" and "┌" into "┬"
+}
+
+type drawing struct {
+	lines    Paragraph
+	settings DrawSettings
+}
+
+// cell is a terminal cell of the Canvas.
+type cell struct {
+	text  string // grapheme cluster, "" for the second cell of a wide character
+	style string // active SGR sequences
+}
+
+// NewCanvas creates and returns a new blank Canvas of a given size.
+// - width is the number of columns, limited to MultiStringsMaxWidth.
+// - height is the number of rows.
+func NewCanvas(width int, height int) *Canvas {
+	return &Canvas{width: minint(maxint(width, 0), MultiStringsMaxWidth), height: maxint(height, 0)}
+}
+
+// Draw draws a Paragraph slice on the Canvas. The parts outside the Canvas are clipped.
+// - lines is the Paragraph slice to draw.
+// - settings holds the coordinates, the z-order and the transparent characters.
+func (c *Canvas) Draw(lines Paragraph, settings DrawSettings) *Canvas {
+	c.drawings = append(c.drawings, drawing{lines, settings})
+	return c
+}
+
+// DrawAt draws an opaque Paragraph slice on the Canvas at given coordinates, over the previous drawings of z-order 0.
+// - lines is the Paragraph slice to draw.
+// - x and y are the column and the row of its top left corner.
+func (c *Canvas) DrawAt(lines Paragraph, x int, y int) *Canvas {
+	return c.Draw(lines, DrawSettings{X: x, Y: y})
+}
+
+// Paragraph composites the drawings and returns the content of the Canvas.
+// The cells where nothing is drawn are spaces.
+func (c *Canvas) Paragraph() (linesOut Paragraph) {
+	grid := make([][]cell, c.height)
+	for y := range grid {
+		grid[y] = make([]cell, c.width)
+		for x := range grid[y] {
+			grid[y][x].text = " "
+		}
+	}
+	drawings := make([]drawing, len(c.drawings))
+	copy(drawings, c.drawings)
+	sort.SliceStable(drawings, func(i, j int) bool { return drawings[i].settings.Z < drawings[j].settings.Z })
+	for _, d := range drawings {
+		transparent := map[string]bool{}
+		for s := StripANSI(d.settings.Transparent); s != ""; {
+			var cluster string
+			cluster, s = nextGrapheme(s)
+			transparent[cluster] = true
+		}
+		for i, line := range d.lines {
+			if y := d.settings.Y + i; y >= 0 && y < c.height {
+				drawLine(grid[y], line, d.settings.X, transparent, d.settings.MergeLines)
+			}
+		}
+	}
+	linesOut = NewWithGivenLen(c.height)
+	for y, row := range grid {
+		linesOut[y] = renderCells(row)
+	}
+	return
+}
+
+// drawLine draws a line on a row of cells from the column x.
+// - transparent is the set of the grapheme clusters which are not drawn.
+// - mergeLines tells whether the box-drawing characters are joined to the ones already drawn.
+func drawLine(row []cell, line string, x int, transparent map[string]bool, mergeLines bool) {
+	var state sgrState
+	style := ""
+	for line != "" {
+		token, escape, rest := nextToken(line)
+		line = rest
+		if escape {
+			if isSGR(token) {
+				state.apply(token)
+				style = state.sequence()
+			}
+			continue
+		}
+		w := graphemeWidth(token)
+		if w == 0 {
+			continue
+		}
+		if !transparent[token] {
+			for k := 0; k < w; k++ {
+				if x+k < 0 || x+k >= len(row) {
+					continue
+				}
+				switch {
+				case x < 0 || x+w > len(row): // wide character cut by an edge
+					setCell(row, x+k, cell{" ", style})
+				case k == 0:
+					text := token
+					if mergeLines {
+						if merged := mergeLineArt(row[x].text, token); merged != "" {
+							text = merged
+						}
+					}
+					setCell(row, x, cell{text, style})
+				default:
+					setCell(row, x+k, cell{"", style})
+				}
+			}
+		}
+		x += w
+	}
+}
+
+// setCell sets a cell of a row, replacing by spaces the remaining halves of the wide characters it overwrites.
+func setCell(row []cell, x int, c cell) {
+	if row[x].text == "" && x > 0 && c.text != "" {
+		for i := x - 1; i >= 0; i-- { // lead cell of the overwritten wide character
+			if row[i].text != "" {
+				row[i].text = " "
+				break
+			}
+			row[i].text = " "
+		}
+	}
+	if row[x].text != "" {
+		for i := x + 1; i < len(row) && row[i].text == ""; i++ {
+			row[i].text = " "
+		}
+	}
+	row[x] = c
+}
+
+// renderCells returns the line made of a row of cells, with the escape sequences changing their styles.
+func renderCells(row []cell) string {
+	var sb strings.Builder
+	style := ""
+	for _, c := range row {
+		if c.text == "" {
+			continue
+		}
+		if c.style != style {
+			if style != "" {
+				sb.WriteString(sgrReset)
+			}
+			sb.WriteString(c.style)
+			style = c.style
+		}
+		sb.WriteString(c.text)
+	}
+	if style != "" {
+		sb.WriteString(sgrReset)
+	}
+	return sb.String()
+}
@@ -0,0 +1,69 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// ColumnsSettings{Count: 3, Width: 80, Order: FillOrderColumnMajor, Gutter: " ", Rule: BoxStyleSingleLine}
+type ColumnsSettings struct {
+	Count  int       // number of columns
+	Width  int       // total width, the gutters and the rules included
+	Order  FillOrder // the lines fill the first column then the next ones, or the first row then the next ones
+	Gutter string    // written between two columns, on both sides of the rule if any
+	Rule   BoxStyle  // the LeftBorder glyph of the style is drawn between the columns, BoxStyleNone for none
+}
+
+// Columns flows the lines of the Paragraph slice into several columns, like in a newspaper.
+// The columns have balanced heights, and share the width left by the gutters and the rules,
+// the leftmost ones being one character wider when it can't be evenly shared.
+// The lines wider than the columns are split with Limit.
+// - settings holds the number of columns, the total width, the filling order and the separators.
+func (linesIn Paragraph) Columns(settings ColumnsSettings) (linesOut Paragraph) {
+	if settings.Count < 1 || settings.Width < 1 || settings.Width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	separator := settings.Gutter
+	if settings.Rule != BoxStyleNone {
+		separator = settings.Gutter + GetBoxPattern(settings.Rule).LeftBorder + settings.Gutter
+	}
+	available := settings.Width - (settings.Count-1)*DisplayWidth(separator)
+	width := available / settings.Count
+	if width < 1 {
+		return linesIn
+	}
+	lines := linesIn.Limit(width)
+	rows := (len(lines) + settings.Count - 1) / settings.Count
+	// In column-major order, the first full columns hold rows lines and the next ones rows-1
+	full := len(lines) % settings.Count
+	if full == 0 {
+		full = settings.Count
+	}
+	linesOut = NewWithGivenLen(rows)
+	var sb strings.Builder
+	for r := 0; r < rows; r++ {
+		sb.Reset()
+		for c := 0; c < settings.Count; c++ {
+			if c > 0 {
+				sb.WriteString(separator)
+			}
+			i, height := c*rows-maxint(c-full, 0)+r, rows
+			if c >= full {
+				height--
+			}
+			if settings.Order == FillOrderRowMajor {
+				i, height = r*settings.Count+c, rows
+			}
+			line := ""
+			if r < height && i < len(lines) {
+				line = lines[i]
+			}
+			w := width
+			if c < available%settings.Count {
+				w++
+			}
+			sb.WriteString(padRight(line, " ", w, DisplayWidth))
+		}
+		linesOut[r] = sb.String()
+	}
+	return
+}
@@ -0,0 +1,110 @@
+package paragraph
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+)
+
+// CSVSettings{Delimiter: '\t', Header: CSVHeaderPresent}
+type CSVSettings struct {
+	Delimiter rune      // ',' when 0, '\t' for TSV
+	Header    CSVHeader // whether the first record is a header row, detected with CSVHeaderAuto
+}
+
+// ReadCSV reads CSV or TSV records, quoted as described in RFC 4180, and returns them as a Table.
+// The records can have different numbers of fields, and the quoted fields can hold several lines.
+// With CSVHeaderAuto, the first record is a header when none of its fields is a number
+// while a column of the next records holds one.
+// - r is the reader from which to read the records.
+// - settings holds the delimiter and tells whether the first record is a header.
+func ReadCSV(r io.Reader, settings CSVSettings) (table Table, err error) {
+	reader := csv.NewReader(r)
+	if settings.Delimiter != 0 {
+		reader.Comma = settings.Delimiter
+	}
+	reader.FieldsPerRecord = -1
+	records, err := reader.ReadAll()
+	if err != nil {
+		return table, fmt.Errorf("Unable to read CSV records: %w", err)
+	}
+	if len(records) > 0 && (settings.Header == CSVHeaderPresent || (settings.Header == CSVHeaderAuto && isCSVHeader(records))) {
+		table.Header = NewTableRow(records[0]...)
+		records = records[1:]
+	}
+	table.Rows = make([][]TableCell, len(records))
+	for i, record := range records {
+		table.Rows[i] = NewTableRow(record...)
+	}
+	return
+}
+
+// NewFromCSV reads CSV or TSV records and returns them rendered as a Table.
+// - r is the reader from which to read the records.
+// - csvSettings holds the delimiter and tells whether the first record is a header.
+// - tableSettings holds the styles, the maximum width of the columns and their alignments.
+func NewFromCSV(r io.Reader, csvSettings CSVSettings, tableSettings TableSettings) (Paragraph, error) {
+	table, err := ReadCSV(r, csvSettings)
+	if err != nil {
+		return nil, err
+	}
+	return table.Render(tableSettings), nil
+}
+
+// WriteCSV writes the header and the rows of the Table as CSV or TSV records, quoted as described in RFC 4180.
+// The lines of a cell are joined with "\n", and a cell spanning several columns is followed by empty fields.
+// - w is the writer to which to write the records.
+// - settings holds the delimiter and tells whether the header is written, CSVHeaderAbsent leaving it out.
+func (table Table) WriteCSV(w io.Writer, settings CSVSettings) error {
+	writer := csv.NewWriter(w)
+	if settings.Delimiter != 0 {
+		writer.Comma = settings.Delimiter
+	}
+	rows := table.Rows
+	if table.Header != nil && settings.Header != CSVHeaderAbsent {
+		rows = append([][]TableCell{table.Header}, rows...)
+	}
+	for _, row := range rows {
+		record := make([]string, 0, len(row))
+		for _, c := range row {
+			record = append(record, strings.Join(c.Lines, "\n"))
+			for k := 1; k < c.span(); k++ {
+				record = append(record, "")
+			}
+		}
+		if err := writer.Write(record); err != nil {
+			return fmt.Errorf("Unable to write CSV record: %w", err)
+		}
+	}
+	writer.Flush()
+	if err := writer.Error(); err != nil {
+		return fmt.Errorf("Unable to write CSV record: %w", err)
+	}
+	return nil
+}
+
+// isCSVHeader tells whether the first record looks like a header row:
+// none of its fields is a number while a column of the next records holds one.
+func isCSVHeader(records [][]string) bool {
+	for _, field := range records[0] {
+		if isNumber(field) {
+			return false
+		}
+	}
+	for _, record := range records[1:] {
+		for i, field := range record {
+			if i < len(records[0]) && isNumber(field) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isNumber tells whether s holds a number, such as "42", "-3.5" or "1e6".
+func isNumber(s string) bool {
+	_, err := strconv.ParseFloat(strings.TrimSpace(s), 64)
+	return err == nil
+}
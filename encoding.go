@@ -0,0 +1,207 @@
+package paragraph
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// ErrInvalidUTF8 is wrapped by the errors reporting invalid UTF-8 sequences.
+var ErrInvalidUTF8 = errors.New("Invalid UTF-8 sequence")
+
+// windows1252 holds the characters of the bytes 0x80 to 0x9F in Windows-1252,
+// the undefined ones being mapped to the C1 control characters as browsers do.
+var windows1252 = [32]rune{
+	'€', '\u0081', '‚', 'ƒ', '„', '…', '†', '‡', 'ˆ', '‰', 'Š', '‹', 'Œ', '\u008d', 'Ž', '\u008f',
+	'\u0090', '‘', '’', '“', '”', '•', '–', '—', '˜', '™', 'š', '›', 'œ', '\u009d', 'ž', 'Ÿ',
+}
+
+// BOM returns the byte order mark of the encoding, such as "\xef\xbb\xbf" for EncodingUTF8,
+// and "" for the encodings which have none.
+func (encoding Encoding) BOM() string {
+	switch encoding {
+	case EncodingUTF8:
+		return "\xef\xbb\xbf"
+	case EncodingUTF16LE:
+		return "\xff\xfe"
+	case EncodingUTF16BE:
+		return "\xfe\xff"
+	}
+	return ""
+}
+
+// ValidateUTF8 returns an error wrapping ErrInvalidUTF8 for each line of the Paragraph slice holding an invalid UTF-8 sequence,
+// with the number of the line and the offset of the first invalid byte, or nil when every line is valid.
+func (lines Paragraph) ValidateUTF8() error {
+	var errs []error
+	for i, s := range lines {
+		if offset := invalidUTF8Offset(s); offset >= 0 {
+			errs = append(errs, fmt.Errorf("Line %d, byte %d: %w", i+1, offset, ErrInvalidUTF8))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// ToValidUTF8 returns a Paragraph slice in which each run of invalid UTF-8 bytes is replaced by a given string,
+// so that the lines can be measured and cut.
+// - replacement is the string replacing the invalid sequences, such as "�" or "?".
+func (linesIn Paragraph) ToValidUTF8(replacement string) (linesOut Paragraph) {
+	linesOut = NewWithGivenLen(len(linesIn))
+	for i, s := range linesIn {
+		linesOut[i] = strings.ToValidUTF8(s, replacement)
+	}
+	return
+}
+
+// invalidUTF8Offset returns the offset of the first invalid UTF-8 byte of s, or -1 when s is valid.
+func invalidUTF8Offset(s string) int {
+	if utf8.ValidString(s) {
+		return -1
+	}
+	for i, r := range s {
+		if r == utf8.RuneError {
+			if _, size := utf8.DecodeRuneInString(s[i:]); size == 1 {
+				return i
+			}
+		}
+	}
+	return -1
+}
+
+// decoder is a reader returning in UTF-8 the text read in a given encoding.
+type decoder struct {
+	r        *bufio.Reader
+	encoding Encoding
+	pending  []byte // the decoded bytes which did not fit in the last read
+}
+
+// newDecoder returns a reader decoding the text read in a given encoding,
+// or in the encoding of the byte order mark starting the text, which is skipped.
+// It returns the encoding of the text and whether it starts with a byte order mark.
+func newDecoder(r io.Reader, encoding Encoding) (d io.Reader, encodingOut Encoding, bom bool) {
+	br := bufio.NewReader(r)
+	for _, e := range []Encoding{EncodingUTF8, EncodingUTF16LE, EncodingUTF16BE} {
+		if b, _ := br.Peek(len(e.BOM())); string(b) == e.BOM() {
+			br.Discard(len(b))
+			encoding, bom = e, true
+			break
+		}
+	}
+	if encoding == EncodingUTF8 {
+		return br, encoding, bom
+	}
+	return &decoder{r: br, encoding: encoding}, encoding, bom
+}
+
+// Read implements the io.Reader interface.
+func (d *decoder) Read(p []byte) (n int, err error) {
+	for len(d.pending) < len(p) {
+		r, errr := d.readRune()
+		if errr != nil {
+			err = errr
+			break
+		}
+		d.pending = utf8.AppendRune(d.pending, r)
+	}
+	n = copy(p, d.pending)
+	d.pending = d.pending[n:]
+	if n > 0 && err == io.EOF {
+		err = nil // returned by the next read
+	}
+	return
+}
+
+// readRune reads and decodes one character.
+func (d *decoder) readRune() (r rune, err error) {
+	switch d.encoding {
+	case EncodingLatin1, EncodingWindows1252:
+		b, err := d.r.ReadByte()
+		if err != nil {
+			return 0, err
+		}
+		if d.encoding == EncodingWindows1252 && b >= 0x80 && b < 0xa0 {
+			return windows1252[b-0x80], nil
+		}
+		return rune(b), nil
+	}
+	unit, err := d.readUnit()
+	if err != nil || !utf16.IsSurrogate(rune(unit)) {
+		return rune(unit), err
+	}
+	if unit < 0xdc00 { // high surrogate, followed by a low one
+		if b, _ := d.r.Peek(2); len(b) == 2 {
+			if r = utf16.DecodeRune(rune(unit), rune(d.decodeUnit(b))); r != utf8.RuneError {
+				d.r.Discard(2)
+				return r, nil
+			}
+		}
+	}
+	return utf8.RuneError, nil
+}
+
+// readUnit reads a UTF-16 code unit, an odd byte at the end of the text being decoded as U+FFFD.
+func (d *decoder) readUnit() (uint16, error) {
+	var b [2]byte
+	n, err := io.ReadFull(d.r, b[:])
+	if n == 1 {
+		return utf8.RuneError, nil
+	}
+	if err != nil {
+		return 0, err
+	}
+	return d.decodeUnit(b[:]), nil
+}
+
+// decodeUnit decodes the two bytes of a UTF-16 code unit.
+func (d *decoder) decodeUnit(b []byte) uint16 {
+	if d.encoding == EncodingUTF16BE {
+		return uint16(b[0])<<8 | uint16(b[1])
+	}
+	return uint16(b[1])<<8 | uint16(b[0])
+}
+
+// encode appends s, encoded in a given encoding, to dst and returns the extended buffer.
+// The characters which cannot be encoded in Latin-1 or Windows-1252 are replaced by "?",
+// and the invalid UTF-8 bytes are encoded as U+FFFD, or "?".
+func encode(dst []byte, s string, encoding Encoding) []byte {
+	if encoding == EncodingUTF8 {
+		return append(dst, s...)
+	}
+	for _, r := range s {
+		switch encoding {
+		case EncodingUTF16LE, EncodingUTF16BE:
+			for _, unit := range utf16.AppendRune(nil, r) {
+				if encoding == EncodingUTF16BE {
+					dst = append(dst, byte(unit>>8), byte(unit))
+				} else {
+					dst = append(dst, byte(unit), byte(unit>>8))
+				}
+			}
+		default:
+			dst = append(dst, encodeByte(r, encoding))
+		}
+	}
+	return dst
+}
+
+// encodeByte returns the Latin-1 or Windows-1252 byte of a character, or '?' when it has none.
+func encodeByte(r rune, encoding Encoding) byte {
+	if encoding == EncodingWindows1252 {
+		for i, c := range windows1252 {
+			if c == r {
+				return byte(0x80 + i)
+			}
+		}
+		if r >= 0x80 && r < 0xa0 {
+			return '?'
+		}
+	}
+	if r < 0x100 {
+		return byte(r)
+	}
+	return '?'
+}
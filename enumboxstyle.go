@@ -15,9 +15,9 @@ import (
 type BoxStyle int
 
 const (
-	BoxStyleCount     = 21
-	BoxStyleMaxIndex  = int(BoxStyleFantasy4)
-	BoxStyleLastValue = BoxStyleFantasy4
+	BoxStyleCount     = 22
+	BoxStyleMaxIndex  = int(BoxStyleAscii)
+	BoxStyleLastValue = BoxStyleAscii
 )
 
 const (
@@ -42,6 +42,7 @@ const (
 	BoxStyleFantasy2
 	BoxStyleFantasy3
 	BoxStyleFantasy4
+	BoxStyleAscii
 )
 
 func (v BoxStyle) String() string {
@@ -67,6 +68,7 @@ func (v BoxStyle) String() string {
 		"BoxStyleFantasy2",
 		"BoxStyleFantasy3",
 		"BoxStyleFantasy4",
+		"BoxStyleAscii",
 	}[v]
 }
 
@@ -123,6 +125,8 @@ func BoxStyleFromString(s string) (BoxStyle, error) {
 		return BoxStyleFantasy3, nil
 	case "Fantasy4":
 		return BoxStyleFantasy4, nil
+	case "Ascii":
+		return BoxStyleAscii, nil
 	}
 	return BoxStyle(0), errors.New("String does not correspond to any existing BoxStyle values")
 }
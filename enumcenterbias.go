@@ -0,0 +1,52 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/CenterBias.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type CenterBias int
+
+const (
+	CenterBiasCount     = 2
+	CenterBiasMaxIndex  = int(CenterBiasRight)
+	CenterBiasLastValue = CenterBiasRight
+)
+
+const (
+	CenterBiasLeft CenterBias = iota
+	CenterBiasRight
+)
+
+func (v CenterBias) String() string {
+	return [...]string{
+		"CenterBiasLeft",
+		"CenterBiasRight",
+	}[v]
+}
+
+func CenterBiasFromString(s string) (CenterBias, error) {
+	var suffix string
+	if strings.HasPrefix(s, "CenterBias") {
+		l := len("CenterBias")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Left":
+		return CenterBiasLeft, nil
+	case "Right":
+		return CenterBiasRight, nil
+	}
+	return CenterBias(0), errors.New("String does not correspond to any existing CenterBias values")
+}
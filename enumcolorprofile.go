@@ -0,0 +1,60 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/ColorProfile.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type ColorProfile int
+
+const (
+	ColorProfileCount     = 4
+	ColorProfileMaxIndex  = int(ColorProfileTrueColor)
+	ColorProfileLastValue = ColorProfileTrueColor
+)
+
+const (
+	ColorProfileNoColor ColorProfile = iota
+	ColorProfileANSI
+	ColorProfileANSI256
+	ColorProfileTrueColor
+)
+
+func (v ColorProfile) String() string {
+	return [...]string{
+		"ColorProfileNoColor",
+		"ColorProfileANSI",
+		"ColorProfileANSI256",
+		"ColorProfileTrueColor",
+	}[v]
+}
+
+func ColorProfileFromString(s string) (ColorProfile, error) {
+	var suffix string
+	if strings.HasPrefix(s, "ColorProfile") {
+		l := len("ColorProfile")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "NoColor":
+		return ColorProfileNoColor, nil
+	case "ANSI":
+		return ColorProfileANSI, nil
+	case "ANSI256":
+		return ColorProfileANSI256, nil
+	case "TrueColor":
+		return ColorProfileTrueColor, nil
+	}
+	return ColorProfile(0), errors.New("String does not correspond to any existing ColorProfile values")
+}
@@ -0,0 +1,56 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/CSVHeader.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type CSVHeader int
+
+const (
+	CSVHeaderCount     = 3
+	CSVHeaderMaxIndex  = int(CSVHeaderAbsent)
+	CSVHeaderLastValue = CSVHeaderAbsent
+)
+
+const (
+	CSVHeaderAuto CSVHeader = iota
+	CSVHeaderPresent
+	CSVHeaderAbsent
+)
+
+func (v CSVHeader) String() string {
+	return [...]string{
+		"CSVHeaderAuto",
+		"CSVHeaderPresent",
+		"CSVHeaderAbsent",
+	}[v]
+}
+
+func CSVHeaderFromString(s string) (CSVHeader, error) {
+	var suffix string
+	if strings.HasPrefix(s, "CSVHeader") {
+		l := len("CSVHeader")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Auto":
+		return CSVHeaderAuto, nil
+	case "Present":
+		return CSVHeaderPresent, nil
+	case "Absent":
+		return CSVHeaderAbsent, nil
+	}
+	return CSVHeader(0), errors.New("String does not correspond to any existing CSVHeader values")
+}
@@ -0,0 +1,64 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/Encoding.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type Encoding int
+
+const (
+	EncodingCount     = 5
+	EncodingMaxIndex  = int(EncodingWindows1252)
+	EncodingLastValue = EncodingWindows1252
+)
+
+const (
+	EncodingUTF8 Encoding = iota
+	EncodingUTF16LE
+	EncodingUTF16BE
+	EncodingLatin1
+	EncodingWindows1252
+)
+
+func (v Encoding) String() string {
+	return [...]string{
+		"EncodingUTF8",
+		"EncodingUTF16LE",
+		"EncodingUTF16BE",
+		"EncodingLatin1",
+		"EncodingWindows1252",
+	}[v]
+}
+
+func EncodingFromString(s string) (Encoding, error) {
+	var suffix string
+	if strings.HasPrefix(s, "Encoding") {
+		l := len("Encoding")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "UTF8":
+		return EncodingUTF8, nil
+	case "UTF16LE":
+		return EncodingUTF16LE, nil
+	case "UTF16BE":
+		return EncodingUTF16BE, nil
+	case "Latin1":
+		return EncodingLatin1, nil
+	case "Windows1252":
+		return EncodingWindows1252, nil
+	}
+	return Encoding(0), errors.New("String does not correspond to any existing Encoding values")
+}
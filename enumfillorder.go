@@ -0,0 +1,52 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/FillOrder.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type FillOrder int
+
+const (
+	FillOrderCount     = 2
+	FillOrderMaxIndex  = int(FillOrderRowMajor)
+	FillOrderLastValue = FillOrderRowMajor
+)
+
+const (
+	FillOrderColumnMajor FillOrder = iota
+	FillOrderRowMajor
+)
+
+func (v FillOrder) String() string {
+	return [...]string{
+		"FillOrderColumnMajor",
+		"FillOrderRowMajor",
+	}[v]
+}
+
+func FillOrderFromString(s string) (FillOrder, error) {
+	var suffix string
+	if strings.HasPrefix(s, "FillOrder") {
+		l := len("FillOrder")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "ColumnMajor":
+		return FillOrderColumnMajor, nil
+	case "RowMajor":
+		return FillOrderRowMajor, nil
+	}
+	return FillOrder(0), errors.New("String does not correspond to any existing FillOrder values")
+}
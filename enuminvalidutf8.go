@@ -0,0 +1,56 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/InvalidUTF8.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type InvalidUTF8 int
+
+const (
+	InvalidUTF8Count     = 3
+	InvalidUTF8MaxIndex  = int(InvalidUTF8Replace)
+	InvalidUTF8LastValue = InvalidUTF8Replace
+)
+
+const (
+	InvalidUTF8Keep InvalidUTF8 = iota
+	InvalidUTF8Report
+	InvalidUTF8Replace
+)
+
+func (v InvalidUTF8) String() string {
+	return [...]string{
+		"InvalidUTF8Keep",
+		"InvalidUTF8Report",
+		"InvalidUTF8Replace",
+	}[v]
+}
+
+func InvalidUTF8FromString(s string) (InvalidUTF8, error) {
+	var suffix string
+	if strings.HasPrefix(s, "InvalidUTF8") {
+		l := len("InvalidUTF8")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Keep":
+		return InvalidUTF8Keep, nil
+	case "Report":
+		return InvalidUTF8Report, nil
+	case "Replace":
+		return InvalidUTF8Replace, nil
+	}
+	return InvalidUTF8(0), errors.New("String does not correspond to any existing InvalidUTF8 values")
+}
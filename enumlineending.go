@@ -0,0 +1,56 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/LineEnding.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type LineEnding int
+
+const (
+	LineEndingCount     = 3
+	LineEndingMaxIndex  = int(LineEndingCR)
+	LineEndingLastValue = LineEndingCR
+)
+
+const (
+	LineEndingLF LineEnding = iota
+	LineEndingCRLF
+	LineEndingCR
+)
+
+func (v LineEnding) String() string {
+	return [...]string{
+		"LineEndingLF",
+		"LineEndingCRLF",
+		"LineEndingCR",
+	}[v]
+}
+
+func LineEndingFromString(s string) (LineEnding, error) {
+	var suffix string
+	if strings.HasPrefix(s, "LineEnding") {
+		l := len("LineEnding")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "LF":
+		return LineEndingLF, nil
+	case "CRLF":
+		return LineEndingCRLF, nil
+	case "CR":
+		return LineEndingCR, nil
+	}
+	return LineEnding(0), errors.New("String does not correspond to any existing LineEnding values")
+}
@@ -0,0 +1,60 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/TextAlign.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type TextAlign int
+
+const (
+	TextAlignCount     = 4
+	TextAlignMaxIndex  = int(TextAlignJustify)
+	TextAlignLastValue = TextAlignJustify
+)
+
+const (
+	TextAlignLeft TextAlign = iota
+	TextAlignCenter
+	TextAlignRight
+	TextAlignJustify
+)
+
+func (v TextAlign) String() string {
+	return [...]string{
+		"TextAlignLeft",
+		"TextAlignCenter",
+		"TextAlignRight",
+		"TextAlignJustify",
+	}[v]
+}
+
+func TextAlignFromString(s string) (TextAlign, error) {
+	var suffix string
+	if strings.HasPrefix(s, "TextAlign") {
+		l := len("TextAlign")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Left":
+		return TextAlignLeft, nil
+	case "Center":
+		return TextAlignCenter, nil
+	case "Right":
+		return TextAlignRight, nil
+	case "Justify":
+		return TextAlignJustify, nil
+	}
+	return TextAlign(0), errors.New("String does not correspond to any existing TextAlign values")
+}
@@ -0,0 +1,56 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/VerticalAlign.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type VerticalAlign int
+
+const (
+	VerticalAlignCount     = 3
+	VerticalAlignMaxIndex  = int(VerticalAlignBottom)
+	VerticalAlignLastValue = VerticalAlignBottom
+)
+
+const (
+	VerticalAlignTop VerticalAlign = iota
+	VerticalAlignMiddle
+	VerticalAlignBottom
+)
+
+func (v VerticalAlign) String() string {
+	return [...]string{
+		"VerticalAlignTop",
+		"VerticalAlignMiddle",
+		"VerticalAlignBottom",
+	}[v]
+}
+
+func VerticalAlignFromString(s string) (VerticalAlign, error) {
+	var suffix string
+	if strings.HasPrefix(s, "VerticalAlign") {
+		l := len("VerticalAlign")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Top":
+		return VerticalAlignTop, nil
+	case "Middle":
+		return VerticalAlignMiddle, nil
+	case "Bottom":
+		return VerticalAlignBottom, nil
+	}
+	return VerticalAlign(0), errors.New("String does not correspond to any existing VerticalAlign values")
+}
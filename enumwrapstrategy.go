@@ -0,0 +1,52 @@
+package paragraph
+
+// IMPORTANT: This file was auto-generated by goenum.exe and should not be modified directly.
+// Any changes made to this file will be overwritten the next time goenum.exe is run.
+// This file was generated based on the original description file located at ./goenum/WrapStrategy.goenum.
+// The template used to generate this file can be found at ./goenum/goenum.template.
+// To make changes to the enumeration, please update the original description file and re-run goenum.exe.
+// The source code for goenum can be found here https://github.com/tpfeiffer67/goenum
+
+import (
+	"errors"
+	"strings"
+)
+
+type WrapStrategy int
+
+const (
+	WrapStrategyCount     = 2
+	WrapStrategyMaxIndex  = int(WrapStrategyOptimal)
+	WrapStrategyLastValue = WrapStrategyOptimal
+)
+
+const (
+	WrapStrategyGreedy WrapStrategy = iota
+	WrapStrategyOptimal
+)
+
+func (v WrapStrategy) String() string {
+	return [...]string{
+		"WrapStrategyGreedy",
+		"WrapStrategyOptimal",
+	}[v]
+}
+
+func WrapStrategyFromString(s string) (WrapStrategy, error) {
+	var suffix string
+	if strings.HasPrefix(s, "WrapStrategy") {
+		l := len("WrapStrategy")
+		if l < len(s) {
+			suffix = s[l:]
+		}
+	} else {
+		suffix = s
+	}
+	switch suffix {
+	case "Greedy":
+		return WrapStrategyGreedy, nil
+	case "Optimal":
+		return WrapStrategyOptimal, nil
+	}
+	return WrapStrategy(0), errors.New("String does not correspond to any existing WrapStrategy values")
+}
@@ -0,0 +1,159 @@
+package paragraph
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"math/rand"
+	"os"
+	"path/filepath"
+	"strconv"
+)
+
+// FileSettings{Atomic: true, Backup: "~"}
+type FileSettings struct {
+	Format      *TextFormat // the line ending, the final newline and the encoding, nil means DefaultTextFormat as for WriteToFile
+	Atomic      bool        // the lines are written to a temporary file which then replaces the file, so that it is never half-written
+	Backup      string      // suffix of the name of the copy of the previous file, such as "~" or ".bak", "" for no backup
+	NoOverwrite bool        // an error wrapping fs.ErrExist is returned when the file exists
+	Perm        fs.FileMode // permissions of a new file before the umask, 0 for 0666, an existing file keeps its own
+}
+
+// WriteFile writes the Paragraph slice to a file with a given filename.
+// In atomic mode, the lines are written and synced to a temporary file of the same directory,
+// which is then renamed to the file, so that a crash leaves either the previous file or the new one.
+// The permissions of an existing file are kept, and a symbolic link is followed so that the link is not replaced by the file.
+// - fileName is the name of the file to which to write the slice.
+// - settings holds the format of the text and tells whether the write is atomic, whether the previous file is backed up
+// and whether an existing file may be overwritten.
+func (lines Paragraph) WriteFile(fileName string, settings FileSettings) (err error) {
+	format := DefaultTextFormat
+	if settings.Format != nil {
+		format = *settings.Format
+	}
+	perm := settings.Perm
+	if perm == 0 {
+		perm = 0666
+	}
+	info, err := os.Stat(fileName)
+	exists := err == nil
+	if err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return fmt.Errorf("Unable to stat file with given file name: %w", err)
+	}
+	if exists {
+		if settings.NoOverwrite {
+			return fmt.Errorf("Unable to overwrite file with given file name: %w", fs.ErrExist)
+		}
+		if fileName, err = filepath.EvalSymlinks(fileName); err != nil {
+			return fmt.Errorf("Unable to resolve file with given file name: %w", err)
+		}
+		if settings.Backup != "" {
+			if err = copyFile(fileName, fileName+settings.Backup, info.Mode().Perm()); err != nil {
+				return fmt.Errorf("Unable to back up file: %w", err)
+			}
+		}
+	}
+	if !settings.Atomic {
+		flag := os.O_WRONLY | os.O_CREATE | os.O_TRUNC
+		if settings.NoOverwrite {
+			flag |= os.O_EXCL
+		}
+		f, err := os.OpenFile(fileName, flag, perm)
+		if err != nil {
+			return fmt.Errorf("Unable to create file with given file name: %w", err)
+		}
+		return lines.writeAndClose(f, format)
+	}
+
+	f, err := createTemp(fileName, perm)
+	if err != nil {
+		return fmt.Errorf("Unable to create temporary file: %w", err)
+	}
+	defer func() {
+		if err != nil {
+			os.Remove(f.Name())
+		}
+	}()
+	if exists {
+		if err = f.Chmod(info.Mode().Perm()); err != nil {
+			f.Close()
+			return fmt.Errorf("Unable to set permissions of temporary file: %w", err)
+		}
+	}
+	if err = lines.writeAndClose(f, format); err != nil {
+		return
+	}
+	if settings.NoOverwrite {
+		// unlike a rename, a link fails when the file has been created in the meantime
+		if err = os.Link(f.Name(), fileName); err != nil {
+			return fmt.Errorf("Unable to create file with given file name: %w", err)
+		}
+		os.Remove(f.Name())
+	} else if err = os.Rename(f.Name(), fileName); err != nil {
+		return fmt.Errorf("Unable to replace file with given file name: %w", err)
+	}
+	syncDir(filepath.Dir(fileName))
+	return nil
+}
+
+// writeAndClose writes the lines to a file in a given format, syncs and closes it.
+func (lines Paragraph) writeAndClose(f *os.File, format TextFormat) (err error) {
+	defer func() {
+		if errc := f.Close(); errc != nil && err == nil {
+			err = fmt.Errorf("Unable to close file: %w", errc)
+		}
+	}()
+	if _, err = lines.WriteToFormat(f, format); err != nil {
+		return
+	}
+	if err = f.Sync(); err != nil {
+		return fmt.Errorf("Unable to sync file: %w", err)
+	}
+	return
+}
+
+// createTemp creates a new hidden file in the directory of a given file, named after it.
+// Unlike os.CreateTemp, the permissions are given, the umask being applied.
+func createTemp(fileName string, perm fs.FileMode) (f *os.File, err error) {
+	dir, base := filepath.Split(fileName)
+	for try := 0; try < 100; try++ {
+		name := filepath.Join(dir, "."+base+"."+strconv.FormatUint(rand.Uint64(), 36)+".tmp")
+		f, err = os.OpenFile(name, os.O_RDWR|os.O_CREATE|os.O_EXCL, perm)
+		if !errors.Is(err, fs.ErrExist) {
+			return
+		}
+	}
+	return
+}
+
+// copyFile copies a file to another one, which is replaced if it exists.
+func copyFile(source string, destination string, perm fs.FileMode) (err error) {
+	in, err := os.Open(source)
+	if err != nil {
+		return
+	}
+	defer in.Close()
+	out, err := os.OpenFile(destination, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, perm)
+	if err != nil {
+		return
+	}
+	defer func() {
+		if errc := out.Close(); errc != nil && err == nil {
+			err = errc
+		}
+	}()
+	if _, err = io.Copy(out, in); err != nil {
+		return
+	}
+	return out.Sync()
+}
+
+// syncDir syncs a directory so that a rename in it is durable.
+// The error is ignored, since the directories cannot be synced on every system.
+func syncDir(dir string) {
+	if d, err := os.Open(dir); err == nil {
+		d.Sync()
+		d.Close()
+	}
+}
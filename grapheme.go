@@ -0,0 +1,197 @@
+package paragraph
+
+import (
+	"unicode"
+	"unicode/utf8"
+)
+
+// Grapheme cluster break properties, as defined by Unicode Standard Annex #29.
+type graphemeProperty int
+
+const (
+	gpOther graphemeProperty = iota
+	gpCR
+	gpLF
+	gpControl
+	gpExtend
+	gpZWJ
+	gpRegionalIndicator
+	gpPrepend
+	gpSpacingMark
+	gpL
+	gpV
+	gpT
+	gpLV
+	gpLVT
+)
+
+// nextGrapheme returns the first extended grapheme cluster of s and the rest of the string.
+// A grapheme cluster is what a user perceives as a single character, such as "é" written as e + combining acute,
+// a flag made of two regional indicators or a family emoji made of several emoji joined by ZWJ.
+func nextGrapheme(s string) (cluster string, rest string) {
+	r, size := utf8.DecodeRuneInString(s)
+	prev := getGraphemeProperty(r)
+	pictographic := isExtendedPictographic(r) // in an Extended_Pictographic Extend* sequence
+	pictographicZWJ := false                  // the sequence above is followed by a ZWJ
+	regionalIndicators := 0
+	if prev == gpRegionalIndicator {
+		regionalIndicators = 1
+	}
+	i := size
+	for i < len(s) {
+		r, size = utf8.DecodeRuneInString(s[i:])
+		p := getGraphemeProperty(r)
+		pict := isExtendedPictographic(r)
+		if !graphemeJoins(prev, p, pictographicZWJ && pict, regionalIndicators) {
+			break
+		}
+		switch {
+		case pict:
+			pictographic, pictographicZWJ = true, false
+		case p == gpExtend && pictographic:
+		case p == gpZWJ && pictographic:
+			pictographic, pictographicZWJ = false, true
+		default:
+			pictographic, pictographicZWJ = false, false
+		}
+		if p == gpRegionalIndicator {
+			regionalIndicators++
+		} else {
+			regionalIndicators = 0
+		}
+		prev = p
+		i += size
+	}
+	return s[:i], s[i:]
+}
+
+// graphemeJoins reports whether there is no grapheme cluster boundary between two characters.
+// - joinedPictographic is true when the second character is an Extended_Pictographic preceded by Extended_Pictographic Extend* ZWJ.
+// - regionalIndicators is the number of consecutive regional indicators before the second character.
+func graphemeJoins(prev graphemeProperty, next graphemeProperty, joinedPictographic bool, regionalIndicators int) bool {
+	switch {
+	case prev == gpCR && next == gpLF: // GB3
+		return true
+	case prev == gpControl || prev == gpCR || prev == gpLF: // GB4
+		return false
+	case next == gpControl || next == gpCR || next == gpLF: // GB5
+		return false
+	case prev == gpL && (next == gpL || next == gpV || next == gpLV || next == gpLVT): // GB6
+		return true
+	case (prev == gpLV || prev == gpV) && (next == gpV || next == gpT): // GB7
+		return true
+	case (prev == gpLVT || prev == gpT) && next == gpT: // GB8
+		return true
+	case next == gpExtend || next == gpZWJ || next == gpSpacingMark: // GB9, GB9a
+		return true
+	case prev == gpPrepend: // GB9b
+		return true
+	case prev == gpZWJ && joinedPictographic: // GB11
+		return true
+	case prev == gpRegionalIndicator && next == gpRegionalIndicator: // GB12, GB13
+		return regionalIndicators%2 == 1
+	}
+	return false // GB999
+}
+
+// graphemeWidth returns the number of terminal cells needed to display a grapheme cluster.
+// The width is the one of the first visible character, or 2 for flags and emoji presentation sequences.
+func graphemeWidth(cluster string) (width int) {
+	for i, r := range cluster {
+		if i == 0 && getGraphemeProperty(r) == gpRegionalIndicator {
+			return 2
+		}
+		if width == 0 {
+			width = runeWidth(r)
+		} else if r == 0xFE0F { // emoji presentation selector
+			return 2
+		}
+	}
+	return
+}
+
+func getGraphemeProperty(r rune) graphemeProperty {
+	switch {
+	case r == '\r':
+		return gpCR
+	case r == '\n':
+		return gpLF
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return gpControl
+	case r < 0x300:
+		if r == 0xAD {
+			return gpControl
+		}
+		return gpOther
+	case r == 0x200D:
+		return gpZWJ
+	case r >= 0x1F1E6 && r <= 0x1F1FF:
+		return gpRegionalIndicator
+	case r >= 0xAC00 && r <= 0xD7A3:
+		if (r-0xAC00)%28 == 0 {
+			return gpLV
+		}
+		return gpLVT
+	case (r >= 0x1100 && r <= 0x115F) || (r >= 0xA960 && r <= 0xA97C):
+		return gpL
+	case (r >= 0x1160 && r <= 0x11A7) || (r >= 0xD7B0 && r <= 0xD7C6):
+		return gpV
+	case (r >= 0x11A8 && r <= 0x11FF) || (r >= 0xD7CB && r <= 0xD7FB):
+		return gpT
+	case inRanges(r, prependRanges):
+		return gpPrepend
+	case inRanges(r, extendRanges), unicode.In(r, unicode.Mn, unicode.Me):
+		return gpExtend
+	case unicode.In(r, unicode.Cc, unicode.Cf, unicode.Zl, unicode.Zp):
+		return gpControl
+	case r == 0x0E33 || r == 0x0EB3, unicode.Is(unicode.Mc, r):
+		return gpSpacingMark
+	}
+	return gpOther
+}
+
+func isExtendedPictographic(r rune) bool {
+	return r >= 0xA9 && inRanges(r, extendedPictographicRanges)
+}
+
+// prependRanges lists the characters with the Prepend grapheme cluster break property.
+var prependRanges = []runeRange{
+	{0x0600, 0x0605}, {0x06DD, 0x06DD}, {0x070F, 0x070F}, {0x0890, 0x0891},
+	{0x08E2, 0x08E2}, {0x0D4E, 0x0D4E}, {0x110BD, 0x110BD}, {0x110CD, 0x110CD},
+	{0x111C2, 0x111C3}, {0x1193F, 0x1193F}, {0x11941, 0x11941}, {0x11A3A, 0x11A3A},
+	{0x11A84, 0x11A89}, {0x11D46, 0x11D46},
+}
+
+// extendRanges lists the characters with the Extend grapheme cluster break property
+// that are not in the Mn or Me categories.
+var extendRanges = []runeRange{
+	{0x09BE, 0x09BE}, {0x09D7, 0x09D7}, {0x0B3E, 0x0B3E}, {0x0B57, 0x0B57},
+	{0x0BBE, 0x0BBE}, {0x0BD7, 0x0BD7}, {0x0CC2, 0x0CC2}, {0x0CD5, 0x0CD6},
+	{0x0D3E, 0x0D3E}, {0x0D57, 0x0D57}, {0x0DCF, 0x0DCF}, {0x0DDF, 0x0DDF},
+	{0x200C, 0x200C}, {0x302E, 0x302F}, {0xFF9E, 0xFF9F}, {0x1D165, 0x1D165},
+	{0x1D16E, 0x1D172}, {0x1F3FB, 0x1F3FF}, {0xE0020, 0xE007F},
+}
+
+// extendedPictographicRanges lists the characters with the Extended_Pictographic property.
+var extendedPictographicRanges = []runeRange{
+	{0x00A9, 0x00A9}, {0x00AE, 0x00AE}, {0x203C, 0x203C}, {0x2049, 0x2049},
+	{0x2122, 0x2122}, {0x2139, 0x2139}, {0x2194, 0x2199}, {0x21A9, 0x21AA},
+	{0x231A, 0x231B}, {0x2328, 0x2328}, {0x2388, 0x2388}, {0x23CF, 0x23CF},
+	{0x23E9, 0x23F3}, {0x23F8, 0x23FA}, {0x24C2, 0x24C2}, {0x25AA, 0x25AB},
+	{0x25B6, 0x25B6}, {0x25C0, 0x25C0}, {0x25FB, 0x25FE}, {0x2600, 0x2605},
+	{0x2607, 0x2612}, {0x2614, 0x2685}, {0x2690, 0x2705}, {0x2708, 0x2712},
+	{0x2714, 0x2714}, {0x2716, 0x2716}, {0x271D, 0x271D}, {0x2721, 0x2721},
+	{0x2728, 0x2728}, {0x2733, 0x2734}, {0x2744, 0x2744}, {0x2747, 0x2747},
+	{0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755}, {0x2757, 0x2757},
+	{0x2763, 0x2767}, {0x2795, 0x2797}, {0x27A1, 0x27A1}, {0x27B0, 0x27B0},
+	{0x27BF, 0x27BF}, {0x2934, 0x2935}, {0x2B05, 0x2B07}, {0x2B1B, 0x2B1C},
+	{0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x3030, 0x3030}, {0x303D, 0x303D},
+	{0x3297, 0x3297}, {0x3299, 0x3299}, {0x1F000, 0x1F0FF}, {0x1F10D, 0x1F10F},
+	{0x1F12F, 0x1F12F}, {0x1F16C, 0x1F171}, {0x1F17E, 0x1F17F}, {0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A}, {0x1F1AD, 0x1F1E5}, {0x1F201, 0x1F20F}, {0x1F21A, 0x1F21A},
+	{0x1F22F, 0x1F22F}, {0x1F232, 0x1F23A}, {0x1F23C, 0x1F23F}, {0x1F249, 0x1F3FA},
+	{0x1F400, 0x1F53D}, {0x1F546, 0x1F64F}, {0x1F680, 0x1F6FF}, {0x1F774, 0x1F77F},
+	{0x1F7D5, 0x1F7FF}, {0x1F80C, 0x1F80F}, {0x1F848, 0x1F84F}, {0x1F85A, 0x1F85F},
+	{0x1F888, 0x1F88F}, {0x1F8AE, 0x1F8FF}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945},
+	{0x1F947, 0x1FAFF}, {0x1FC00, 0x1FFFD},
+}
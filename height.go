@@ -0,0 +1,62 @@
+package paragraph
+
+// HeightSettings{Height: 10, VerticalAlign: VerticalAlignMiddle, OverflowMarker: "…"}
+type HeightSettings struct {
+	Height         int           // number of lines, the extra lines are removed, 0 or less for the line count of the Paragraph
+	VerticalAlign  VerticalAlign // alignment of the lines when the Paragraph has less lines than the height
+	OverflowMarker string        // line replacing the last kept line when lines are removed, "" for none
+}
+
+// PadHeight pads the Paragraph slice with blank lines to a given height.
+// The Paragraph is returned unchanged if it already has this height or more lines.
+// - height is the desired number of lines.
+// - align tells where the lines are placed: at the top, in the middle (leaning to the top) or at the bottom.
+func (linesIn Paragraph) PadHeight(height int, align VerticalAlign) Paragraph {
+	return fitHeight(linesIn, height, align, "", false, "")
+}
+
+// TruncateHeight truncates the Paragraph slice to a given height.
+// When lines are removed and an overflow marker is given, the last kept line is replaced by the marker,
+// so that the reader knows the content goes on.
+// - height is the maximum number of lines.
+// - overflowMarker is the line replacing the last kept line, such as "…" or "[more]", or "" for none.
+func (linesIn Paragraph) TruncateHeight(height int, overflowMarker string) Paragraph {
+	return fitHeight(linesIn, height, VerticalAlignTop, overflowMarker, true, "")
+}
+
+// FitHeight forces the Paragraph slice to an exact height, padding it with blank lines
+// like PadHeight, or truncating it like TruncateHeight.
+// - height is the desired number of lines.
+// - align tells where the lines are placed when they are padded.
+// - overflowMarker is the line replacing the last kept line when they are truncated, or "" for none.
+func (linesIn Paragraph) FitHeight(height int, align VerticalAlign, overflowMarker string) Paragraph {
+	return fitHeight(linesIn, height, align, overflowMarker, true, "")
+}
+
+// fitHeight pads linesIn with blank lines to a given height and truncates it if required.
+// - blank is the content of the added lines.
+func fitHeight(linesIn Paragraph, height int, align VerticalAlign, overflowMarker string, truncate bool, blank string) (linesOut Paragraph) {
+	l := len(linesIn)
+	if height < 0 || l == height || (l > height && !truncate) {
+		return linesIn
+	}
+	linesOut = NewWithPresetContent(blank, height)
+	if l > height {
+		copy(linesOut, linesIn[:height])
+		if overflowMarker != "" && height > 0 {
+			linesOut[height-1] = overflowMarker
+		}
+		return
+	}
+	copy(linesOut[verticalOffset(l, height, align):], linesIn)
+	return
+}
+
+// fit forces linesIn to the height of the settings, if any.
+// - blank is the content of the added lines.
+func (settings HeightSettings) fit(linesIn Paragraph, blank string) Paragraph {
+	if settings.Height < 1 {
+		return linesIn
+	}
+	return fitHeight(linesIn, settings.Height, settings.VerticalAlign, settings.OverflowMarker, true, blank)
+}
@@ -0,0 +1,205 @@
+package paragraph
+
+import (
+	"bufio"
+	_ "embed"
+	"fmt"
+	"io"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+const softHyphen = "\u00AD"
+
+// Hyphenator finds the legal hyphenation points of words with Liang's algorithm,
+// the one used by TeX, from a set of patterns and a list of exceptions.
+type Hyphenator struct {
+	patterns   map[string][]uint8 // letters of the pattern -> values between the letters
+	maxLength  int                // length in runes of the longest pattern
+	exceptions map[string][]int   // word -> positions of the hyphens, in runes
+	leftMin    int                // minimal number of letters before a hyphen
+	rightMin   int                // minimal number of letters after a hyphen
+}
+
+// NewHyphenator creates and returns a new Hyphenator.
+// - patterns are Liang patterns in the TeX format, such as "1b2l" or ".ach4".
+// - exceptions are hyphenated words, such as "as-so-ciate", overriding the patterns.
+// - leftMin and rightMin are the minimal number of letters before and after a hyphen.
+func NewHyphenator(patterns []string, exceptions []string, leftMin int, rightMin int) *Hyphenator {
+	h := &Hyphenator{
+		patterns:   make(map[string][]uint8, len(patterns)),
+		exceptions: make(map[string][]int, len(exceptions)),
+		leftMin:    maxint(leftMin, 1),
+		rightMin:   maxint(rightMin, 1),
+	}
+	for _, p := range patterns {
+		h.addPattern(p)
+	}
+	for _, e := range exceptions {
+		h.addException(e)
+	}
+	return h
+}
+
+// ParseHyphenator reads patterns and exceptions in the TeX format and returns a new Hyphenator.
+// The content of the \patterns{...} and \hyphenation{...} groups is read, the comments starting with % are ignored.
+// If the reader holds no group, every word is read as a pattern, as in the hyph-*.pat.txt files.
+// - r is the reader from which to read the patterns.
+// - leftMin and rightMin are the minimal number of letters before and after a hyphen.
+func ParseHyphenator(r io.Reader, leftMin int, rightMin int) (*Hyphenator, error) {
+	var patterns, exceptions []string
+	list := &patterns
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		line := scanner.Text()
+		if i := strings.IndexByte(line, '%'); i >= 0 {
+			line = line[:i]
+		}
+		for _, field := range strings.Fields(line) {
+			switch {
+			case strings.HasPrefix(field, `\patterns{`):
+				list = &patterns
+				field = field[len(`\patterns{`):]
+			case strings.HasPrefix(field, `\hyphenation{`):
+				list = &exceptions
+				field = field[len(`\hyphenation{`):]
+			case strings.HasPrefix(field, `\`):
+				continue
+			}
+			field = strings.TrimRight(field, "}")
+			if field != "" {
+				*list = append(*list, field)
+			}
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("Unable to read hyphenation patterns: %w", err)
+	}
+	return NewHyphenator(patterns, exceptions, leftMin, rightMin), nil
+}
+
+func (h *Hyphenator) addPattern(pattern string) {
+	var letters strings.Builder
+	values := make([]uint8, 1, len(pattern))
+	for _, r := range pattern {
+		if r >= '0' && r <= '9' {
+			values[len(values)-1] = uint8(r - '0')
+		} else {
+			letters.WriteRune(unicode.ToLower(r))
+			values = append(values, 0)
+		}
+	}
+	key := letters.String()
+	h.patterns[key] = values
+	h.maxLength = maxint(h.maxLength, utf8.RuneCountInString(key))
+}
+
+func (h *Hyphenator) addException(exception string) {
+	var word strings.Builder
+	var positions []int
+	n := 0
+	for _, r := range exception {
+		if r == '-' {
+			positions = append(positions, n)
+		} else {
+			word.WriteRune(unicode.ToLower(r))
+			n++
+		}
+	}
+	h.exceptions[word.String()] = positions
+}
+
+// Hyphenate returns the parts of a word between its legal hyphenation points.
+// - word is the word to hyphenate. Its leading and trailing punctuation is kept with the first and the last parts.
+func (h *Hyphenator) Hyphenate(word string) (parts []string) {
+	previous := 0
+	for _, p := range h.points(word) {
+		parts = append(parts, word[previous:p])
+		previous = p
+	}
+	return append(parts, word[previous:])
+}
+
+// points returns the byte offsets of the legal hyphenation points of a word.
+func (h *Hyphenator) points(word string) (offsets []int) {
+	if h == nil {
+		return nil
+	}
+	start := strings.IndexFunc(word, unicode.IsLetter)
+	end := strings.LastIndexFunc(word, unicode.IsLetter)
+	if start < 0 {
+		return nil
+	}
+	_, size := utf8.DecodeRuneInString(word[end:])
+	core := word[start : end+size]
+	runes := []rune(core)
+	for i, r := range runes {
+		if !unicode.IsLetter(r) { // compound words, digits, escape sequences... are not hyphenated
+			return nil
+		}
+		runes[i] = unicode.ToLower(r)
+	}
+	n := len(runes)
+	if n < h.leftMin+h.rightMin {
+		return nil
+	}
+	var positions []int
+	if exception, found := h.exceptions[string(runes)]; found {
+		positions = exception
+	} else {
+		// values[i] is the value between the letters i-1 and i of the word surrounded by dots
+		padded := append(append([]rune{'.'}, runes...), '.')
+		values := make([]uint8, len(padded)+1)
+		for i := 0; i < len(padded); i++ {
+			for l := 1; l <= h.maxLength && i+l <= len(padded); l++ {
+				if pattern, found := h.patterns[string(padded[i:i+l])]; found {
+					for k, v := range pattern {
+						if v > values[i+k] {
+							values[i+k] = v
+						}
+					}
+				}
+			}
+		}
+		for i := 1; i < n; i++ {
+			if values[i+1]%2 == 1 {
+				positions = append(positions, i)
+			}
+		}
+	}
+	// The positions in runes are converted to byte offsets in the word
+	offset, index := start, 0
+	for _, p := range positions {
+		if p < h.leftMin || n-p < h.rightMin {
+			continue
+		}
+		for ; index < p; index++ {
+			_, size := utf8.DecodeRuneInString(word[offset:])
+			offset += size
+		}
+		offsets = append(offsets, offset)
+	}
+	return
+}
+
+// HyphenatorEnglish is a Hyphenator for American English using the TeX patterns of the hyph-utf8 project (hyph-en-us).
+var HyphenatorEnglish = mustParseHyphenator(hyphEnUS, 2, 3)
+
+// HyphenatorFrench is a Hyphenator for French using the TeX patterns of the hyph-utf8 project (hyph-fr).
+var HyphenatorFrench = mustParseHyphenator(hyphFr, 2, 3)
+
+//go:embed hyphenation/hyph-en-us.tex
+var hyphEnUS string
+
+//go:embed hyphenation/hyph-fr.tex
+var hyphFr string
+
+// mustParseHyphenator parses the embedded patterns, which can't fail to be read.
+func mustParseHyphenator(patterns string, leftMin int, rightMin int) *Hyphenator {
+	h, err := ParseHyphenator(strings.NewReader(patterns), leftMin, rightMin)
+	if err != nil {
+		panic(err)
+	}
+	return h
+}
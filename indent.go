@@ -0,0 +1,92 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// Indent adds a given prefix at the beginning of each line of the Paragraph slice which is not blank.
+// - prefix is the string to add, such as spaces, "// " or "> ".
+func (linesIn Paragraph) Indent(prefix string) (linesOut Paragraph) {
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		if isBlank(linesIn[i]) {
+			linesOut[i] = linesIn[i]
+		} else {
+			linesOut[i] = prefix + linesIn[i]
+		}
+	}
+	return
+}
+
+// Dedent removes the leading spaces and tabs common to all the lines of the Paragraph slice which are not blank.
+// The blank lines are emptied.
+func (linesIn Paragraph) Dedent() (linesOut Paragraph) {
+	margin, found := "", false
+	for _, s := range linesIn {
+		if isBlank(s) {
+			continue
+		}
+		indent := s[:len(s)-len(strings.TrimLeft(s, " \t"))]
+		if !found {
+			margin, found = indent, true
+			continue
+		}
+		n := 0
+		for n < len(margin) && n < len(indent) && margin[n] == indent[n] {
+			n++
+		}
+		margin = margin[:n]
+	}
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		if !isBlank(linesIn[i]) {
+			linesOut[i] = linesIn[i][len(margin):]
+		}
+	}
+	return
+}
+
+// WrapPrefixed wraps each line of the Paragraph slice like Wrap does, the first line of each wrapped string
+// starting with a given prefix and the next ones with another one, such as a list marker and its hanging indent.
+// The width of the prefixes is taken into account, so that the lines don't exceed the width of the settings.
+// Unlike Wrap, the left-aligned lines, and the last lines of the justified strings, are not padded with spaces.
+// - settings holds the width, the alignment and the line breaking strategy, as for Wrap.
+// - firstPrefix is the prefix of the first line, such as "- " or "// ".
+// - nextPrefix is the prefix of the continuation lines, such as "  " or "// ".
+func (linesIn Paragraph) WrapPrefixed(settings WrapSettings, firstPrefix string, nextPrefix string) (linesOut Paragraph) {
+	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	linesOut = New(len(linesIn))
+	for _, s := range linesIn {
+		linesOut = appendPrefixed(linesOut, s, firstPrefix, nextPrefix, settings)
+	}
+	return
+}
+
+// appendPrefixed appends to linesOut the lines obtained by wrapping s, prefixed by firstPrefix for the first one
+// and by nextPrefix for the next ones.
+func appendPrefixed(linesOut Paragraph, s string, firstPrefix string, nextPrefix string, settings WrapSettings) Paragraph {
+	firstWidth := maxint(settings.Width-DisplayWidth(firstPrefix), 1)
+	settings.Width = maxint(settings.Width-DisplayWidth(nextPrefix), 1)
+	first := len(linesOut)
+	linesOut = appendWrapped(linesOut, s, settings, firstWidth)
+	for i := first; i < len(linesOut); i++ {
+		if settings.Align == TextAlignLeft || settings.Align == TextAlignJustify {
+			linesOut[i] = strings.TrimRight(linesOut[i], " ") // no trailing spaces in comments and lists
+		}
+		if i == first {
+			linesOut[i] = firstPrefix + linesOut[i]
+		} else {
+			linesOut[i] = nextPrefix + linesOut[i]
+		}
+	}
+	return linesOut
+}
+
+// isBlank tells whether a line holds nothing visible but spaces.
+func isBlank(s string) bool {
+	return strings.TrimSpace(StripANSI(s)) == ""
+}
@@ -0,0 +1,193 @@
+package paragraph
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"strings"
+	"unicode/utf8"
+)
+
+// DefaultMaxLineLength is the maximum length in bytes of the lines read by ReadFrom and ReadFromFile.
+const DefaultMaxLineLength = 1024 * 1024
+
+// ReadSettings{Encoding: EncodingWindows1252, InvalidUTF8: InvalidUTF8Replace}
+type ReadSettings struct {
+	MaxLineLength int         // maximum length in bytes of a line once decoded, DefaultMaxLineLength when it is not positive
+	Encoding      Encoding    // encoding of the text, unless it starts with a byte order mark
+	InvalidUTF8   InvalidUTF8 // whether the invalid UTF-8 sequences are kept, reported as an error or replaced by U+FFFD
+}
+
+// NewFromReader creates and returns a new Paragraph slice from the lines read from a reader.
+// The lines end with "\n", "\r\n" or "\r", the line ending is not kept.
+// - r is the reader from which to read the lines.
+// - maxLineLength is the maximum length in bytes of a line, an error is returned for a longer line.
+// DefaultMaxLineLength is used when it is not positive.
+func NewFromReader(r io.Reader, maxLineLength int) (linesOut Paragraph, err error) {
+	linesOut, _, err = NewFromReaderFormat(r, maxLineLength)
+	return
+}
+
+// NewFromReaderFormat creates and returns a new Paragraph slice from the lines read from a reader,
+// and the format of the text, so that WriteToFormat writes it back unchanged when all its lines end the same way.
+// - r is the reader from which to read the lines.
+// - maxLineLength is the maximum length in bytes of a line, an error is returned for a longer line.
+// DefaultMaxLineLength is used when it is not positive.
+func NewFromReaderFormat(r io.Reader, maxLineLength int) (linesOut Paragraph, format TextFormat, err error) {
+	return ReadParagraph(r, ReadSettings{MaxLineLength: maxLineLength})
+}
+
+// ReadParagraph creates and returns a new Paragraph slice from the lines of a text read from a reader in a given encoding,
+// and the format of the text, so that WriteToFormat writes it back unchanged when all its lines end the same way.
+// A byte order mark at the start of the text takes precedence over the encoding of the settings,
+// and is kept in the format so that it is written back.
+// - r is the reader from which to read the text.
+// - settings holds the maximum length of the lines, the encoding of the text and what to do with invalid UTF-8 sequences.
+func ReadParagraph(r io.Reader, settings ReadSettings) (linesOut Paragraph, format TextFormat, err error) {
+	linesOut = New(0)
+	_, format, err = linesOut.readFrom(r, settings)
+	return
+}
+
+// ReadFromFile creates and returns a new Paragraph slice from the lines of a file.
+// - fileName is the name of the file from which to read the lines.
+func ReadFromFile(fileName string) (linesOut Paragraph, err error) {
+	linesOut, _, err = ReadFromFileFormat(fileName)
+	return
+}
+
+// ReadFromFileFormat creates and returns a new Paragraph slice from the lines of a file, and the format of the file.
+// - fileName is the name of the file from which to read the lines.
+func ReadFromFileFormat(fileName string) (linesOut Paragraph, format TextFormat, err error) {
+	return ReadFile(fileName, ReadSettings{})
+}
+
+// ReadFile creates and returns a new Paragraph slice from the lines of a file in a given encoding, and the format of the file.
+// - fileName is the name of the file from which to read the lines.
+// - settings holds the maximum length of the lines, the encoding of the file and what to do with invalid UTF-8 sequences.
+func ReadFile(fileName string, settings ReadSettings) (linesOut Paragraph, format TextFormat, err error) {
+	f, err := os.Open(fileName)
+	if err != nil {
+		return nil, format, fmt.Errorf("Unable to open file with given file name: %w", err)
+	}
+	defer f.Close()
+	return ReadParagraph(f, settings)
+}
+
+// ReadFrom appends to the Paragraph slice the lines read from a reader, until EOF.
+// It implements the io.ReaderFrom interface, the lines being limited to DefaultMaxLineLength bytes.
+// - r is the reader from which to read the lines.
+func (lines *Paragraph) ReadFrom(r io.Reader) (n int64, err error) {
+	n, _, err = lines.readFrom(r, ReadSettings{})
+	return
+}
+
+func (lines *Paragraph) readFrom(r io.Reader, settings ReadSettings) (n int64, format TextFormat, err error) {
+	maxLineLength := settings.MaxLineLength
+	if maxLineLength < 1 {
+		maxLineLength = DefaultMaxLineLength
+	}
+	counter := &countingReader{r: r}
+	decoded, encoding, bom := newDecoder(counter, settings.Encoding)
+	format.Encoding, format.BOM = encoding, bom
+	scanner := bufio.NewScanner(decoded)
+	scanner.Buffer(make([]byte, 0, minint(maxLineLength+2, bufio.MaxScanTokenSize)), maxLineLength+2) // +2 for the "\r\n"
+	scanner.Split(lineSplitter(&format))
+	for scanner.Scan() {
+		line := scanner.Text()
+		if len(line) > maxLineLength {
+			return counter.n, format, fmt.Errorf("Unable to read line %d: %w", len(*lines)+1, bufio.ErrTooLong)
+		}
+		switch settings.InvalidUTF8 {
+		case InvalidUTF8Report:
+			if offset := invalidUTF8Offset(line); offset >= 0 {
+				return counter.n, format, fmt.Errorf("Unable to read line %d, byte %d: %w", len(*lines)+1, offset, ErrInvalidUTF8)
+			}
+		case InvalidUTF8Replace:
+			line = strings.ToValidUTF8(line, string(utf8.RuneError))
+		}
+		*lines = append(*lines, line)
+	}
+	if err = scanner.Err(); err != nil {
+		return counter.n, format, fmt.Errorf("Unable to read line %d: %w", len(*lines)+1, err)
+	}
+	return counter.n, format, nil
+}
+
+// WriteTo writes the lines of the Paragraph slice to a writer, each followed by "\n".
+// It implements the io.WriterTo interface.
+// - w is the writer to which to write the lines.
+func (lines Paragraph) WriteTo(w io.Writer) (n int64, err error) {
+	return lines.WriteToFormat(w, DefaultTextFormat)
+}
+
+// WriteToFormat writes the lines of the Paragraph slice to a writer, separated by the line ending of a given format,
+// and followed by one if the format has a final newline.
+// The text is written in the encoding of the format, after its byte order mark if the format has one.
+// The characters which cannot be encoded in Latin-1 or Windows-1252 are written as "?".
+// - w is the writer to which to write the lines.
+// - format is the format of the text to write.
+func (lines Paragraph) WriteToFormat(w io.Writer, format TextFormat) (n int64, err error) {
+	bw := bufio.NewWriter(w)
+	ending := encode(nil, format.LineEnding.Sequence(), format.Encoding)
+	if format.BOM {
+		m, err := bw.WriteString(format.Encoding.BOM())
+		n += int64(m)
+		if err != nil {
+			return n, fmt.Errorf("Unable to write string: %w", err)
+		}
+	}
+	var buf []byte
+	for i, s := range lines {
+		buf = encode(buf, s, format.Encoding)
+		if i < len(lines)-1 || format.FinalNewline {
+			buf = append(buf, ending...)
+		}
+		m, err := bw.Write(buf)
+		n += int64(m)
+		if err != nil {
+			return n, fmt.Errorf("Unable to write string: %w", err)
+		}
+		buf = buf[:0]
+	}
+	if err = bw.Flush(); err != nil {
+		return n, fmt.Errorf("Unable to write string: %w", err)
+	}
+	return
+}
+
+// WriteToSeparator writes the lines of the Paragraph slice to a writer, each followed by a given line separator,
+// as ToString does but without building the string.
+// - w is the writer to which to write the lines.
+// - linesSeparator is a string representing the separator to write after each line.
+func (lines Paragraph) WriteToSeparator(w io.Writer, linesSeparator string) (n int64, err error) {
+	bw := bufio.NewWriter(w)
+	for _, s := range lines {
+		m, err := bw.WriteString(s)
+		n += int64(m)
+		if err == nil {
+			m, err = bw.WriteString(linesSeparator)
+			n += int64(m)
+		}
+		if err != nil {
+			return n, fmt.Errorf("Unable to write string: %w", err)
+		}
+	}
+	if err = bw.Flush(); err != nil {
+		return n, fmt.Errorf("Unable to write string: %w", err)
+	}
+	return
+}
+
+// countingReader counts the bytes read from a reader.
+type countingReader struct {
+	r io.Reader
+	n int64
+}
+
+func (c *countingReader) Read(p []byte) (n int, err error) {
+	n, err = c.r.Read(p)
+	c.n += int64(n)
+	return
+}
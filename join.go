@@ -0,0 +1,61 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// JoinHorizontal places Paragraph slices side by side and returns the joined lines.
+// Each Paragraph is padded with spaces to its width, and the shorter ones are completed with blank lines,
+// so that the columns stay aligned.
+// - align is the vertical alignment of the Paragraphs shorter than the highest one.
+// - gutter is the string inserted between two Paragraphs.
+// - paragraphs are the Paragraph slices to join, from left to right.
+func JoinHorizontal(align VerticalAlign, gutter string, paragraphs ...Paragraph) (linesOut Paragraph) {
+	height := 0
+	widths := make([]int, len(paragraphs))
+	for k, p := range paragraphs {
+		height = maxint(height, len(p))
+		widths[k] = p.Width()
+	}
+	linesOut = NewWithGivenLen(height)
+	var sb strings.Builder
+	for i := 0; i < height; i++ {
+		sb.Reset()
+		for k, p := range paragraphs {
+			if k > 0 {
+				sb.WriteString(gutter)
+			}
+			line := ""
+			if j := i - verticalOffset(len(p), height, align); j >= 0 && j < len(p) {
+				line = p[j]
+			}
+			sb.WriteString(padRight(line, " ", widths[k], DisplayWidth))
+		}
+		linesOut[i] = sb.String()
+	}
+	return
+}
+
+// JoinHorizontal places another Paragraph slice at the right of the current one.
+// - align is the vertical alignment of the shorter Paragraph.
+// - gutter is the string inserted between the two Paragraphs.
+// - linesToJoin is the Paragraph slice to place at the right.
+func (linesIn Paragraph) JoinHorizontal(align VerticalAlign, gutter string, linesToJoin Paragraph) Paragraph {
+	return JoinHorizontal(align, gutter, linesIn, linesToJoin)
+}
+
+// verticalOffset returns the number of blank lines to put above a block of a given height
+// to align it vertically within a total height. A middle-aligned block leans to the top.
+func verticalOffset(height int, total int, align VerticalAlign) int {
+	l := total - height
+	if l <= 0 {
+		return 0
+	}
+	switch align {
+	case VerticalAlignMiddle:
+		return l / 2
+	case VerticalAlignBottom:
+		return l
+	}
+	return 0
+}
@@ -0,0 +1,104 @@
+package paragraph
+
+import (
+	"unicode/utf8"
+)
+
+// lineArms holds the weights of the up, right, down and left arms of a box-drawing character:
+// 0 for none, 1 for light, 2 for heavy and 3 for double.
+type lineArms [4]uint8
+
+// lineArt holds the arms of the box-drawing characters, the dashed and diagonal ones excepted.
+var lineArt = map[rune]lineArms{
+	'─': {0, 1, 0, 1}, '━': {0, 2, 0, 2}, '│': {1, 0, 1, 0}, '┃': {2, 0, 2, 0}, '┌': {0, 1, 1, 0}, '┍': {0, 2, 1, 0},
+	'┎': {0, 1, 2, 0}, '┏': {0, 2, 2, 0}, '┐': {0, 0, 1, 1}, '┑': {0, 0, 1, 2}, '┒': {0, 0, 2, 1}, '┓': {0, 0, 2, 2},
+	'└': {1, 1, 0, 0}, '┕': {1, 2, 0, 0}, '┖': {2, 1, 0, 0}, '┗': {2, 2, 0, 0}, '┘': {1, 0, 0, 1}, '┙': {1, 0, 0, 2},
+	'┚': {2, 0, 0, 1}, '┛': {2, 0, 0, 2}, '├': {1, 1, 1, 0}, '┝': {1, 2, 1, 0}, '┞': {2, 1, 1, 0}, '┟': {1, 1, 2, 0},
+	'┠': {2, 1, 2, 0}, '┡': {2, 2, 1, 0}, '┢': {1, 2, 2, 0}, '┣': {2, 2, 2, 0}, '┤': {1, 0, 1, 1}, '┥': {1, 0, 1, 2},
+	'┦': {2, 0, 1, 1}, '┧': {1, 0, 2, 1}, '┨': {2, 0, 2, 1}, '┩': {2, 0, 1, 2}, '┪': {1, 0, 2, 2}, '┫': {2, 0, 2, 2},
+	'┬': {0, 1, 1, 1}, '┭': {0, 1, 1, 2}, '┮': {0, 2, 1, 1}, '┯': {0, 2, 1, 2}, '┰': {0, 1, 2, 1}, '┱': {0, 1, 2, 2},
+	'┲': {0, 2, 2, 1}, '┳': {0, 2, 2, 2}, '┴': {1, 1, 0, 1}, '┵': {1, 1, 0, 2}, '┶': {1, 2, 0, 1}, '┷': {1, 2, 0, 2},
+	'┸': {2, 1, 0, 1}, '┹': {2, 1, 0, 2}, '┺': {2, 2, 0, 1}, '┻': {2, 2, 0, 2}, '┼': {1, 1, 1, 1}, '┽': {1, 1, 1, 2},
+	'┾': {1, 2, 1, 1}, '┿': {1, 2, 1, 2}, '╀': {2, 1, 1, 1}, '╁': {1, 1, 2, 1}, '╂': {2, 1, 2, 1}, '╃': {2, 1, 1, 2},
+	'╄': {2, 2, 1, 1}, '╅': {1, 1, 2, 2}, '╆': {1, 2, 2, 1}, '╇': {2, 2, 1, 2}, '╈': {1, 2, 2, 2}, '╉': {2, 1, 2, 2},
+	'╊': {2, 2, 2, 1}, '╋': {2, 2, 2, 2}, '═': {0, 3, 0, 3}, '║': {3, 0, 3, 0}, '╒': {0, 3, 1, 0}, '╓': {0, 1, 3, 0},
+	'╔': {0, 3, 3, 0}, '╕': {0, 0, 1, 3}, '╖': {0, 0, 3, 1}, '╗': {0, 0, 3, 3}, '╘': {1, 3, 0, 0}, '╙': {3, 1, 0, 0},
+	'╚': {3, 3, 0, 0}, '╛': {1, 0, 0, 3}, '╜': {3, 0, 0, 1}, '╝': {3, 0, 0, 3}, '╞': {1, 3, 1, 0}, '╟': {3, 1, 3, 0},
+	'╠': {3, 3, 3, 0}, '╡': {1, 0, 1, 3}, '╢': {3, 0, 3, 1}, '╣': {3, 0, 3, 3}, '╤': {0, 3, 1, 3}, '╥': {0, 1, 3, 1},
+	'╦': {0, 3, 3, 3}, '╧': {1, 3, 0, 3}, '╨': {3, 1, 0, 1}, '╩': {3, 3, 0, 3}, '╪': {1, 3, 1, 3}, '╫': {3, 1, 3, 1},
+	'╬': {3, 3, 3, 3}, '╭': {0, 1, 1, 0}, '╮': {0, 0, 1, 1}, '╯': {1, 0, 0, 1}, '╰': {1, 1, 0, 0}, '╴': {0, 0, 0, 1},
+	'╵': {1, 0, 0, 0}, '╶': {0, 1, 0, 0}, '╷': {0, 0, 1, 0}, '╸': {0, 0, 0, 2}, '╹': {2, 0, 0, 0}, '╺': {0, 2, 0, 0},
+	'╻': {0, 0, 2, 0}, '╼': {0, 2, 0, 1}, '╽': {1, 0, 2, 0}, '╾': {0, 1, 0, 2}, '╿': {2, 0, 1, 0},
+}
+
+// lineArtRunes is the reverse of lineArt, the rounded corners excepted.
+var lineArtRunes = func() map[lineArms]rune {
+	runes := make(map[lineArms]rune, len(lineArt))
+	for r, arms := range lineArt {
+		if r < '╭' || r > '╰' {
+			runes[arms] = r
+		}
+	}
+	return runes
+}()
+
+// mergeLineArt returns the box-drawing character joining the arms of two overlapping characters,
+// such as "┬" for "┐" and "┌", or "" if one of them is not a box-drawing character.
+// The ASCII lines "-" and "|" are joined into "+".
+// When no character has the joined arms, because they mix heavy and double lines for instance,
+// the arms of the character below take the weight of the opposite arm of the one above, so that a line
+// keeps its weight when crossing a junction, then the weight of the one above, which is returned unchanged as a last resort.
+// A character whose arms already hold all the joined ones is kept, so the rounded corners stay rounded.
+// - under is the character already drawn.
+// - over is the character drawn over it.
+func mergeLineArt(under string, over string) string {
+	if isASCIILineArt(under) && isASCIILineArt(over) {
+		if under == over {
+			return over
+		}
+		return "+"
+	}
+	ru, nu := utf8.DecodeRuneInString(under)
+	ro, no := utf8.DecodeRuneInString(over)
+	armsUnder, foundUnder := lineArt[ru]
+	armsOver, foundOver := lineArt[ro]
+	if !foundUnder || !foundOver || nu != len(under) || no != len(over) {
+		return ""
+	}
+	weight := uint8(0) // weight of the line above
+	for _, w := range armsOver {
+		if w > weight {
+			weight = w
+		}
+	}
+	for step := 0; step < 3; step++ {
+		var arms lineArms
+		for i := range arms {
+			switch opposite := armsOver[(i+2)%4]; {
+			case armsOver[i] != 0 || armsUnder[i] == 0:
+				arms[i] = armsOver[i]
+			case step == 1 && opposite != 0:
+				arms[i] = opposite
+			case step == 2:
+				arms[i] = weight
+			default:
+				arms[i] = armsUnder[i]
+			}
+		}
+		switch arms {
+		case armsOver:
+			return over
+		case armsUnder:
+			return under
+		}
+		if r, found := lineArtRunes[arms]; found {
+			return string(r)
+		}
+	}
+	return over
+}
+
+// isASCIILineArt tells whether s is one of the characters drawing the ASCII boxes.
+func isASCIILineArt(s string) bool {
+	return s == "-" || s == "|" || s == "+"
+}
@@ -0,0 +1,221 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// BreakPenalties sets the cost of the undesirable line breaks for the optimal wrapping strategy.
+// They are compared to the sum of the squares of the unused widths at the end of the lines.
+type BreakPenalties struct {
+	Widow    int // the last line holds a single word
+	Hyphen   int // a line ends with a hyphen inside a word
+	Overlong int // a word longer than the width is split without a hyphen
+}
+
+var DefaultBreakPenalties = BreakPenalties{Widow: 100, Hyphen: 50, Overlong: 1000}
+
+// breakItem is a part of a line that can't be split by the line breakers.
+type breakItem struct {
+	text    string
+	width   int
+	glue    bool // preceded by a space, unless at the beginning of a line
+	hyphen  bool // a hyphen is added when the line is broken after the item inside a word
+	penalty int  // cost of a break after the item inside a word
+}
+
+// lineWidth returns the width of the line holding the items i to j-1, the added hyphen included.
+func lineWidth(items []breakItem, i int, j int) (width int) {
+	for k := i; k < j; k++ {
+		width += items[k].width
+		if k > i && items[k].glue {
+			width++
+		}
+	}
+	if j < len(items) && !items[j].glue && items[j-1].hyphen {
+		width++
+	}
+	return
+}
+
+// appendGreedy appends to linesOut the lines obtained by splitting s so that none of them exceeds maxWidth,
+// or firstWidth for the first line, putting as many items as possible on each line.
+func appendGreedy(linesOut Paragraph, s string, firstWidth int, maxWidth int, penalties BreakPenalties, hyphenator *Hyphenator) Paragraph {
+	items := breakItems(s, minint(firstWidth, maxWidth), penalties, hyphenator)
+	var breaks []int
+	for i := 0; i < len(items); {
+		limit := maxWidth
+		if i == 0 {
+			limit = firstWidth
+		}
+		j := i + 1
+		for k := i + 2; k <= len(items) && lineWidth(items, i, k-1) <= limit; k++ {
+			if lineWidth(items, i, k) <= limit {
+				j = k
+			}
+		}
+		breaks = append(breaks, j)
+		i = j
+	}
+	return appendItemLines(linesOut, items, breaks)
+}
+
+// appendOptimal appends to linesOut the lines obtained by splitting s so that none of them exceeds maxWidth,
+// or firstWidth for the first line, choosing the breaks that minimize the total cost of the lines (minimum raggedness).
+// The cost of a line is the square of its unused width, except for the last line,
+// plus the penalties of its break.
+func appendOptimal(linesOut Paragraph, s string, firstWidth int, maxWidth int, penalties BreakPenalties, hyphenator *Hyphenator) Paragraph {
+	items := breakItems(s, minint(firstWidth, maxWidth), penalties, hyphenator)
+	n := len(items)
+	// cost[j] is the minimal cost of the lines holding the first j items, from[j] the first item of the last of them
+	cost := make([]int, n+1)
+	from := make([]int, n+1)
+	for j := 1; j <= n; j++ {
+		cost[j] = -1
+		words := 0
+		for i := j - 1; i >= 0; i-- {
+			if i < j-1 && items[i+1].glue {
+				words++
+			}
+			limit := maxWidth
+			if i == 0 {
+				limit = firstWidth
+			}
+			width := lineWidth(items, i, j)
+			if width > maxint(firstWidth, maxWidth) && i < j-1 {
+				break
+			}
+			if width > limit && i < j-1 {
+				continue
+			}
+			c := cost[i]
+			switch {
+			case j < n:
+				c += (limit - width) * (limit - width)
+				if !items[j].glue {
+					c += items[j-1].penalty
+				}
+			case words == 0 && i > 0:
+				c += penalties.Widow
+			}
+			if cost[j] < 0 || c < cost[j] {
+				cost[j], from[j] = c, i
+			}
+		}
+	}
+	// The breaks are collected from the end
+	breaks := make([]int, 0, n)
+	for j := n; j > 0; j = from[j] {
+		breaks = append(breaks, j)
+	}
+	for i, j := 0, len(breaks)-1; i < j; i, j = i+1, j-1 {
+		breaks[i], breaks[j] = breaks[j], breaks[i]
+	}
+	return appendItemLines(linesOut, items, breaks)
+}
+
+// appendItemLines appends to linesOut the lines made of the items between the breaks.
+func appendItemLines(linesOut Paragraph, items []breakItem, breaks []int) Paragraph {
+	if len(items) == 0 {
+		return append(linesOut, "")
+	}
+	start := len(linesOut)
+	i := 0
+	for _, j := range breaks {
+		var sb strings.Builder
+		for k := i; k < j; k++ {
+			if k > i && items[k].glue {
+				sb.WriteByte(' ')
+			}
+			sb.WriteString(items[k].text)
+		}
+		if j < len(items) && !items[j].glue && items[j-1].hyphen {
+			sb.WriteByte('-')
+		}
+		linesOut = append(linesOut, sb.String())
+		i = j
+	}
+	for k := start + 1; k < len(linesOut); k++ {
+		linesOut[k-1], linesOut[k] = carryStyle(linesOut[k-1], linesOut[k])
+	}
+	return linesOut
+}
+
+// breakItems splits s into words, then the words into parts after their hyphens, at their soft hyphens
+// and at the hyphenation points found by the hyphenator, and finally the parts longer than maxWidth
+// into pieces fitting in it.
+func breakItems(s string, maxWidth int, penalties BreakPenalties, hyphenator *Hyphenator) (items []breakItem) {
+	words := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' })
+	items = make([]breakItem, 0, len(words))
+	for _, word := range words {
+		glue := true
+		parts := splitWord(word, hyphenator)
+		for p, part := range parts {
+			text := strings.ReplaceAll(part, softHyphen, "")
+			hyphen := p < len(parts)-1 && !strings.HasSuffix(text, "-")
+			penalty := penalties.Hyphen
+			if p == len(parts)-1 {
+				penalty = 0
+			}
+			limit := maxWidth
+			if hyphen && limit < 2 { // no room for a hyphen, the word is split like an overlong one
+				hyphen, penalty = false, penalties.Overlong
+			} else if hyphen {
+				limit-- // room for the hyphen
+			}
+			for DisplayWidth(text) > limit {
+				first, rest := splitAtWidth(text, limit)
+				if rest == "" { // a grapheme cluster wider than the limit
+					break
+				}
+				items = append(items, breakItem{first, DisplayWidth(first), glue, false, penalties.Overlong})
+				text = rest
+				glue = false
+			}
+			items = append(items, breakItem{text, DisplayWidth(text), glue, hyphen, penalty})
+			glue = false
+		}
+	}
+	if len(items) > 0 {
+		items[0].glue = false
+	}
+	return
+}
+
+// splitWord splits a word after its hyphens, after its soft hyphens and at its hyphenation points.
+func splitWord(word string, hyphenator *Hyphenator) (parts []string) {
+	for word != "" {
+		i := strings.IndexByte(word[1:], '-') + 1
+		if j := strings.Index(word, softHyphen); j > 0 && (i < 1 || j < i) {
+			i = j + len(softHyphen) - 1
+		}
+		part := word
+		if i > 0 && i+1 < len(word) {
+			part = word[:i+1]
+		}
+		word = word[len(part):]
+		if strings.HasSuffix(part, "-") || strings.HasSuffix(part, softHyphen) {
+			parts = append(parts, part)
+		} else {
+			parts = append(parts, hyphenator.Hyphenate(part)...)
+		}
+	}
+	return
+}
+
+// splitAtWidth splits s after its longest prefix not exceeding maxWidth,
+// keeping at least one grapheme cluster in the first part.
+func splitAtWidth(s string, maxWidth int) (first string, second string) {
+	width, visible := 0, false
+	for rest := s; rest != ""; {
+		token, escape, r := nextToken(rest)
+		if !escape {
+			width += graphemeWidth(token)
+			if width > maxWidth && visible {
+				return s[:len(s)-len(rest)], rest
+			}
+			visible = true
+		}
+		rest = r
+	}
+	return s, ""
+}
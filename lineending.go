@@ -0,0 +1,111 @@
+package paragraph
+
+import (
+	"bufio"
+	"bytes"
+	"strings"
+)
+
+// TextFormat describes how the lines of a text are terminated and encoded.
+// TextFormat{LineEnding: LineEndingCRLF, FinalNewline: true, Encoding: EncodingUTF16LE, BOM: true}
+type TextFormat struct {
+	LineEnding   LineEnding // the line ending written between the lines, the first one found when reading
+	FinalNewline bool       // whether the last line is followed by a line ending
+	Encoding     Encoding   // the encoding of the text, ignored by the string functions
+	BOM          bool       // whether the text starts with the byte order mark of its encoding
+}
+
+// DefaultTextFormat is the format written by WriteTo and WriteToFile.
+var DefaultTextFormat = TextFormat{LineEnding: LineEndingLF, FinalNewline: true}
+
+// Sequence returns the characters of the line ending, such as "\r\n" for LineEndingCRLF.
+func (ending LineEnding) Sequence() string {
+	switch ending {
+	case LineEndingCRLF:
+		return "\r\n"
+	case LineEndingCR:
+		return "\r"
+	}
+	return "\n"
+}
+
+// NewFromStringFormat creates and returns a new Paragraph slice from a string whose lines end with "\n", "\r\n" or "\r",
+// and the format of the string, so that ToStringFormat gives it back unchanged
+// when all its lines end the same way.
+// Unlike NewFromString, no empty line is added when the string ends with a line ending.
+// - s is the string from which to create the new Paragraph slice.
+func NewFromStringFormat(s string) (linesOut Paragraph, format TextFormat) {
+	format.LineEnding = LineEndingLF
+	found := false
+	for s != "" {
+		i := strings.IndexAny(s, "\r\n")
+		if i < 0 {
+			format.FinalNewline = false
+			linesOut = append(linesOut, s)
+			break
+		}
+		ending, size := lineEndingAt(s[i:])
+		if !found {
+			format.LineEnding, found = ending, true
+		}
+		format.FinalNewline = true
+		linesOut = append(linesOut, s[:i])
+		s = s[i+size:]
+	}
+	if linesOut == nil {
+		linesOut = Paragraph{}
+	}
+	return
+}
+
+// ToStringFormat joins the lines of the Paragraph slice with the line ending of a given format,
+// adding one after the last line if the format has a final newline.
+// - format is the format of the string to return.
+func (lines Paragraph) ToStringFormat(format TextFormat) string {
+	ending := format.LineEnding.Sequence()
+	var sb strings.Builder
+	sb.Grow(lines.byteLen(ending))
+	for i, s := range lines {
+		sb.WriteString(s)
+		if i < len(lines)-1 || format.FinalNewline {
+			sb.WriteString(ending)
+		}
+	}
+	return sb.String()
+}
+
+// lineEndingAt returns the line ending starting s, which starts with '\r' or '\n', and its length.
+func lineEndingAt(s string) (ending LineEnding, size int) {
+	if s[0] == '\n' {
+		return LineEndingLF, 1
+	}
+	if len(s) > 1 && s[1] == '\n' {
+		return LineEndingCRLF, 2
+	}
+	return LineEndingCR, 1
+}
+
+// lineSplitter returns a bufio.SplitFunc splitting the lines on "\n", "\r\n" and "\r",
+// which records in format the first line ending found and whether the last line ends with one.
+func lineSplitter(format *TextFormat) bufio.SplitFunc {
+	found := false
+	return func(data []byte, atEOF bool) (advance int, token []byte, err error) {
+		if atEOF && len(data) == 0 {
+			return 0, nil, nil
+		}
+		i := bytes.IndexAny(data, "\r\n")
+		if i < 0 || (data[i] == '\r' && i == len(data)-1 && !atEOF) {
+			if !atEOF {
+				return 0, nil, nil // a longer line or the '\n' of a "\r\n" is expected
+			}
+			format.FinalNewline = false
+			return len(data), data, nil
+		}
+		ending, size := lineEndingAt(string(data[i:minint(i+2, len(data))]))
+		if !found {
+			format.LineEnding, found = ending, true
+		}
+		format.FinalNewline = true
+		return i + size, data[:i], nil
+	}
+}
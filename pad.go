@@ -0,0 +1,114 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// PadLeft pads the Paragraph slice on the left side with a given fill pattern to a given width.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+func (linesIn Paragraph) PadLeft(fillPattern string, width int) Paragraph {
+	return linesIn.PadLeftWith(fillPattern, width, DisplayWidth)
+}
+
+// PadLeftWith pads the Paragraph slice on the left side with a given fill pattern to a given width,
+// measured with a given WidthFunc.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - f is the function used to measure each string.
+func (linesIn Paragraph) PadLeftWith(fillPattern string, width int, f WidthFunc) (linesOut Paragraph) {
+	if measure(fillPattern, f) == 0 {
+		return linesIn
+	}
+	if width < 1 || width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		linesOut[i] = fill(fillPattern, width-measure(linesIn[i], f), f) + linesIn[i]
+	}
+	return
+}
+
+// PadCenter pads the Paragraph slice on both sides with a given fill pattern to a given width,
+// so that each line is centered.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - bias is the side the lines lean to when the padding can't be evenly shared.
+// CenterBiasRight gives the extra fill unit to the left side, as the Box labels do.
+func (linesIn Paragraph) PadCenter(fillPattern string, width int, bias CenterBias) Paragraph {
+	return linesIn.PadCenterWith(fillPattern, width, bias, DisplayWidth)
+}
+
+// PadCenterWith pads the Paragraph slice on both sides with a given fill pattern to a given width,
+// measured with a given WidthFunc.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - bias is the side the lines lean to when the padding can't be evenly shared.
+// - f is the function used to measure each string.
+func (linesIn Paragraph) PadCenterWith(fillPattern string, width int, bias CenterBias, f WidthFunc) (linesOut Paragraph) {
+	if measure(fillPattern, f) == 0 {
+		return linesIn
+	}
+	if width < 1 || width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		n := width - measure(linesIn[i], f)
+		left := n / 2
+		if bias == CenterBiasRight {
+			left += n % 2
+		}
+		linesOut[i] = fill(fillPattern, left, f) + linesIn[i] + fill(fillPattern, n-left, f)
+	}
+	return
+}
+
+// PadDecimal aligns the numbers of the Paragraph slice on their decimal separator, then pads them
+// on the left side with a given fill pattern to a given width, as in a numeric column.
+// The integer parts are padded on the left and the fractional parts on the right with the fill pattern,
+// a line without separator being aligned as an integer. When the aligned numbers exceed the width,
+// they are returned aligned but without further padding.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - separator is the decimal separator, such as "." or ",".
+func (linesIn Paragraph) PadDecimal(fillPattern string, width int, separator string) Paragraph {
+	return linesIn.PadDecimalWith(fillPattern, width, separator, DisplayWidth)
+}
+
+// PadDecimalWith aligns the numbers of the Paragraph slice on their decimal separator and pads them
+// to a given width, measured with a given WidthFunc.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - separator is the decimal separator, such as "." or ",".
+// - f is the function used to measure each string.
+func (linesIn Paragraph) PadDecimalWith(fillPattern string, width int, separator string, f WidthFunc) (linesOut Paragraph) {
+	if measure(fillPattern, f) == 0 || separator == "" {
+		return linesIn
+	}
+	if width < 1 || width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	l := len(linesIn)
+	integers := make([]string, l)
+	fractions := make([]string, l) // separator included
+	maxInteger, maxFraction := 0, 0
+	for i := 0; i < l; i++ {
+		integers[i] = linesIn[i]
+		if j := strings.Index(linesIn[i], separator); j >= 0 {
+			integers[i], fractions[i] = linesIn[i][:j], linesIn[i][j:]
+		}
+		maxInteger = maxint(maxInteger, measure(integers[i], f))
+		maxFraction = maxint(maxFraction, measure(fractions[i], f))
+	}
+	maxInteger = maxint(maxInteger, width-maxFraction)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		linesOut[i] = fill(fillPattern, maxInteger-measure(integers[i], f), f) + integers[i] +
+			fractions[i] + fill(fillPattern, maxFraction-measure(fractions[i], f), f)
+	}
+	return
+}
@@ -0,0 +1,98 @@
+package paragraph
+
+import (
+	"errors"
+	"fmt"
+)
+
+// PageSettings{Height: 60, Header: Paragraph{"Report", ""}, Footer: Paragraph{"", "{{page}}/{{pages}}"}}
+type PageSettings struct {
+	Height    int                    // number of lines of a page, the header and the footer included
+	Header    Paragraph              // Mustache template of the lines at the top of each page
+	Footer    Paragraph              // Mustache template of the lines at the bottom of each page
+	Orphans   int                    // minimal number of lines of a paragraph at the bottom of a page, 0 for 2
+	Widows    int                    // minimal number of lines of a paragraph at the top of a page, 0 for 2
+	Variables map[string]interface{} // variables of the templates, in addition to page and pages
+}
+
+// Paginate splits the Paragraph slice into pages of a given height.
+// The paragraphs, separated by blank lines, are not broken before their first lines (orphans)
+// nor before their last lines (widows) when it can be avoided, and the blank lines at the top of the pages are removed.
+// Each page is made of the header, the lines padded with blank lines, and the footer,
+// the header and the footer being rendered with Mustache, {{page}} being the number of the page and {{pages}} the number of pages.
+// - settings holds the height of the pages, the header and footer templates and the orphan and widow limits.
+func (linesIn Paragraph) Paginate(settings PageSettings) (pages []Paragraph, err error) {
+	bodyHeight := settings.Height - len(settings.Header) - len(settings.Footer)
+	if bodyHeight < 1 {
+		return nil, errors.New("Page height too small for the header and the footer")
+	}
+	orphans, widows := settings.Orphans, settings.Widows
+	if orphans == 0 {
+		orphans = 2
+	}
+	if widows == 0 {
+		widows = 2
+	}
+	var bodies []Paragraph
+	for i, n := 0, len(linesIn); i < n; {
+		for len(bodies) > 0 && i < n && isBlank(linesIn[i]) {
+			i++
+		}
+		if i == n {
+			break
+		}
+		end := minint(i+bodyHeight, n)
+		b := end
+		for b > i+1 && !isGoodBreak(linesIn, i, b, orphans, widows) {
+			b--
+		}
+		if b == i+1 && !isGoodBreak(linesIn, i, b, orphans, widows) {
+			b = end
+		}
+		bodies = append(bodies, linesIn[i:b])
+		i = b
+	}
+	if len(bodies) == 0 {
+		bodies = append(bodies, Paragraph{})
+	}
+	pages = make([]Paragraph, len(bodies))
+	for p, body := range bodies {
+		variables := map[string]interface{}{}
+		for k, v := range settings.Variables {
+			variables[k] = v
+		}
+		variables["page"] = p + 1
+		variables["pages"] = len(bodies)
+		header, errh := settings.Header.Mustache(variables)
+		footer, errf := settings.Footer.Mustache(variables)
+		if errh != nil || errf != nil {
+			return nil, fmt.Errorf("Unable to render the header and the footer of page %d: %w", p+1, errors.Join(errh, errf))
+		}
+		page := New(settings.Height)
+		page = append(page, header...)
+		page = append(page, fitHeight(body, bodyHeight, VerticalAlignTop, "", false, "")...)
+		pages[p] = append(page, footer...)
+	}
+	return
+}
+
+// isGoodBreak tells whether a page starting at the line first can be broken before the line b,
+// without leaving less than orphans lines of a paragraph at the bottom of the page
+// nor less than widows lines at the top of the next one.
+func isGoodBreak(lines Paragraph, first int, b int, orphans int, widows int) bool {
+	if b >= len(lines) || isBlank(lines[b-1]) || isBlank(lines[b]) {
+		return true
+	}
+	start := b - 1 // first line of the paragraph
+	for start > 0 && !isBlank(lines[start-1]) {
+		start--
+	}
+	end := b // line after the paragraph
+	for end < len(lines) && !isBlank(lines[end]) {
+		end++
+	}
+	if start >= first && b-start < orphans {
+		return false
+	}
+	return end-b >= widows
+}
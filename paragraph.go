@@ -9,12 +9,8 @@
 package paragraph
 
 import (
-	"fmt"
-	"os"
 	"sort"
 	"strings"
-
-	"github.com/tpfeiffer67/runesstr"
 )
 
 const MultiStringsMaxWidth = 1000
@@ -61,37 +57,64 @@ func NewWithPresetContent(line string, count int) (linesOut Paragraph) {
 }
 
 // NewFromString creates and returns a new Paragraph slice from a string.
-// The given string is split into separate substrings at each occurrence of a newline character.
+// The given string is split into separate substrings at each line ending, "\n", "\r\n" or "\r".
 // - s is a string from which to create the new Paragraph slice.
 func NewFromString(s string) Paragraph {
-	return strings.Split(s, "\n")
+	linesOut, format := NewFromStringFormat(s)
+	if format.FinalNewline || len(linesOut) == 0 {
+		linesOut = append(linesOut, "")
+	}
+	return linesOut
+}
+
+// WriteToFile writes the Paragraph slice to a file with a given filename, each line being followed by "\n".
+// - fileName is the name of the file to which to write the slice.
+func (lines Paragraph) WriteToFile(fileName string) error {
+	return lines.WriteToFileFormat(fileName, DefaultTextFormat)
 }
 
-// WriteToFile writes the Paragraph slice to a file with a given filename.
+// WriteToFileFormat writes the Paragraph slice to a file with a given filename and a given format.
 // - fileName is the name of the file to which to write the slice.
-func (lines Paragraph) WriteToFile(fileName string) (err error) {
-	f, err := os.Create(fileName)
-	defer f.Close()
-	if err != nil {
-		return fmt.Errorf("Unable to create file with given file name: %w", err)
+// - format is the format of the text to write.
+func (lines Paragraph) WriteToFileFormat(fileName string, format TextFormat) error {
+	return lines.WriteFile(fileName, FileSettings{Format: &format})
+}
+
+// ToString concatenates the Paragraph slice into a string with a given line separator.
+// - linesSeparator is a string representing the separator to use between each line.
+func (lines Paragraph) ToString(linesSeparator string) string {
+	var sb strings.Builder
+	sb.Grow(lines.byteLen(linesSeparator))
+	for _, s := range lines {
+		sb.WriteString(s)
+		sb.WriteString(linesSeparator)
+	}
+	return sb.String()
+}
+
+// AppendTo appends the lines of the Paragraph slice, each followed by a given line separator, to a byte slice
+// and returns the extended slice, growing it at most once.
+// - dst is the byte slice to which to append the lines.
+// - linesSeparator is a string representing the separator to write after each line.
+func (lines Paragraph) AppendTo(dst []byte, linesSeparator string) []byte {
+	if n := lines.byteLen(linesSeparator); cap(dst)-len(dst) < n {
+		grown := make([]byte, len(dst), len(dst)+n)
+		copy(grown, dst)
+		dst = grown
 	}
 	for _, s := range lines {
-		_, err = f.WriteString(s + "\n")
-		if err != nil {
-			return fmt.Errorf("Unable to write string to file: %w", err)
-		}
+		dst = append(dst, s...)
+		dst = append(dst, linesSeparator...)
 	}
-	f.Sync()
-	return
+	return dst
 }
 
-// ToString concatenates the Paragraph slice into a string with a given line separator.
-// - linesSeparator is a string representing the separator to use between each line.
-func (lines Paragraph) ToString(linesSeparator string) (result string) {
+// byteLen returns the length in bytes of the lines of the Paragraph slice, each followed by a given line separator.
+func (lines Paragraph) byteLen(linesSeparator string) (n int) {
 	for _, s := range lines {
-		result = result + s + linesSeparator
+		n += len(s)
 	}
-	return
+	return n + len(lines)*len(linesSeparator)
 }
 
 // String implements the Stringer interface for Paragraph.
@@ -99,25 +122,38 @@ func (lines Paragraph) String() string {
 	return lines.ToString("\n")
 }
 
-// Width returns the width of the Paragraph slice, which is the length of the longest string in the slice.
-func (lines Paragraph) Width() (width int) {
+// Width returns the width of the Paragraph slice, which is the display width of the longest string in the slice.
+func (lines Paragraph) Width() int {
+	return lines.WidthWith(DisplayWidth)
+}
+
+// WidthWith returns the width of the Paragraph slice, measured with a given WidthFunc.
+// - f is the function used to measure each string.
+func (lines Paragraph) WidthWith(f WidthFunc) (width int) {
 	for _, s := range lines {
-		width = maxint(runesstr.Length(s), width)
+		width = maxint(measure(s, f), width)
 	}
 	return
 }
 
 // Cut truncates the Paragraph slice to a given maximum width by cutting strings that exceed it.
 // - maxWidth is the maximum width to which to truncate the strings.
-func (linesIn Paragraph) Cut(maxWidth int) (linesOut Paragraph) {
+func (linesIn Paragraph) Cut(maxWidth int) Paragraph {
+	return linesIn.CutWith(maxWidth, DisplayWidth)
+}
+
+// CutWith truncates the Paragraph slice to a given maximum width, measured with a given WidthFunc.
+// - maxWidth is the maximum width to which to truncate the strings.
+// - f is the function used to measure each string.
+func (linesIn Paragraph) CutWith(maxWidth int, f WidthFunc) (linesOut Paragraph) {
 	if maxWidth < 1 {
 		linesOut = linesIn
 		return
 	}
 	linesOut = New(len(linesIn))
 	for _, s := range linesIn {
-		if runesstr.Length(s) > maxWidth {
-			linesOut = append(linesOut, runesstr.Left(s, maxWidth))
+		if measure(s, f) > maxWidth {
+			linesOut = append(linesOut, cut(s, maxWidth, f))
 		} else {
 			linesOut = append(linesOut, s)
 		}
@@ -126,23 +162,23 @@ func (linesIn Paragraph) Cut(maxWidth int) (linesOut Paragraph) {
 }
 
 // Limit truncates the Paragraph slice to a given maximum width by splitting strings that exceed it.
+// The words longer than the width are split without hyphens, use Wrap with a Hyphenator to hyphenate them.
 // - maxWidth is the maximum width to which to truncate the strings.
-func (linesIn Paragraph) Limit(maxWidth int) (linesOut Paragraph) {
+func (linesIn Paragraph) Limit(maxWidth int) Paragraph {
+	return linesIn.LimitWith(maxWidth, DisplayWidth)
+}
+
+// LimitWith truncates the Paragraph slice to a given maximum width, measured with a given WidthFunc,
+// by splitting strings that exceed it.
+// - maxWidth is the maximum width to which to truncate the strings.
+// - f is the function used to measure each string.
+func (linesIn Paragraph) LimitWith(maxWidth int, f WidthFunc) (linesOut Paragraph) {
 	if maxWidth < 1 {
 		return linesIn
 	}
 	linesOut = New(len(linesIn)) // at least the same len than linesIn
 	for _, s := range linesIn {
-		for {
-			var sl string
-			if runesstr.Length(s) > maxWidth {
-				sl, s = runesstr.SplitOnNearestSpace(s, maxWidth)
-				linesOut = append(linesOut, sl)
-			} else {
-				linesOut = append(linesOut, s)
-				break
-			}
-		}
+		linesOut = appendLimited(linesOut, s, maxWidth, maxWidth, f)
 	}
 	return
 }
@@ -150,8 +186,17 @@ func (linesIn Paragraph) Limit(maxWidth int) (linesOut Paragraph) {
 // PadRight pads the Paragraph slice on the right side with a given fill pattern to a given width.
 // - fillPattern represents the pattern to use for padding.
 // - width is the desired width of each line after padding.
-func (linesIn Paragraph) PadRight(fillPattern string, width int) (linesOut Paragraph) {
-	if runesstr.Length(fillPattern) == 0 {
+func (linesIn Paragraph) PadRight(fillPattern string, width int) Paragraph {
+	return linesIn.PadRightWith(fillPattern, width, DisplayWidth)
+}
+
+// PadRightWith pads the Paragraph slice on the right side with a given fill pattern to a given width,
+// measured with a given WidthFunc.
+// - fillPattern represents the pattern to use for padding.
+// - width is the desired width of each line after padding.
+// - f is the function used to measure each string.
+func (linesIn Paragraph) PadRightWith(fillPattern string, width int, f WidthFunc) (linesOut Paragraph) {
+	if measure(fillPattern, f) == 0 {
 		return linesIn
 	}
 	if width < 1 || width > MultiStringsMaxWidth { // Here we set a limit to width
@@ -160,7 +205,7 @@ func (linesIn Paragraph) PadRight(fillPattern string, width int) (linesOut Parag
 	l := len(linesIn)
 	linesOut = NewWithGivenLen(l)
 	for i := 0; i < l; i++ {
-		linesOut[i] = runesstr.PadRight(linesIn[i], fillPattern, width)
+		linesOut[i] = padRight(linesIn[i], fillPattern, width, f)
 	}
 	return
 }
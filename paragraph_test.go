@@ -1,11 +1,19 @@
 package paragraph
 
 import (
+	"bufio"
 	"bytes"
+	"compress/gzip"
+	"errors"
 	"fmt"
+	"io"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"regexp"
+	"strings"
 	"testing"
+	"testing/iotest"
 
 	"github.com/stretchr/testify/assert"
 )
@@ -32,7 +40,9 @@ func TestWidth(t *testing.T) {
 	lns4 = append(lns4, " ")
 	lns4 = append(lns4, "世界")
 	lns4 = append(lns4, "¨")
-	assert.Equal(2, lns4.Width())
+	assert.Equal(4, lns4.Width())
+	assert.Equal(2, lns4.WidthWith(RuneCount))
+	assert.Equal(6, lns4.WidthWith(ByteCount))
 
 	lns5 := NewWithGivenLen(3)
 	lns5[0] = "hippopotomonstrosesquipédaliophobie"
@@ -41,6 +51,177 @@ func TestWidth(t *testing.T) {
 	assert.Equal(35, lns5.Width())
 }
 
+func TestDisplayWidth(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(0, DisplayWidth(""))
+	assert.Equal(10, DisplayWidth("abcde12345"))
+	assert.Equal(4, DisplayWidth("世界"))
+	assert.Equal(1, DisplayWidth("e\u0301"))
+	assert.Equal(0, DisplayWidth("\u200d"))
+	assert.Equal(2, DisplayWidth("😀"))
+	assert.Equal(4, DisplayWidth("ｆｕ"))
+	assert.Equal(1, DisplayWidth("─"))
+}
+
+func TestCutLimitPadRightWidth(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("世界世界 ab")
+	assert.Equal(Paragraph{"世界世"}, lns.Cut(7))
+	assert.Equal(Paragraph{"世界"}, lns.CutWith(7, ByteCount))
+	assert.Equal(Paragraph{"世界世界 "}, lns.CutWith(5, RuneCount))
+	assert.Equal(Paragraph{"世界世", "界 ab"}, lns.Limit(7))
+	assert.Equal(Paragraph{"世界世界", "ab"}, lns.Limit(8))
+	assert.Equal(Paragraph{"世界世界", "ab"}, lns.LimitWith(5, RuneCount))
+	assert.Equal(Paragraph{"世", "界", "世", "界", "a", "b"}, lns.Limit(1))
+	// A grapheme cluster wider than the limit is kept whole on its own line
+	assert.Equal(Paragraph{"世", "界"}, NewFromString("世界").Limit(1))
+	assert.Equal(Paragraph{"😀"}, NewFromString("😀").Limit(1))
+	assert.Equal(Paragraph{"a", "世"}, NewFromString("a世").Limit(1))
+	assert.Equal(Paragraph{"\x1b[1m😀\x1b[0m", "\x1b[1m👍🏽\x1b[0m"}, NewFromString("\x1b[1m😀👍🏽\x1b[0m").Limit(1))
+	assert.Equal(Paragraph{"世界世界 ab...."}, lns.PadRight(".", 15))
+	assert.Equal(Paragraph{"世界世界 ab世 "}, lns.PadRight("世界", 14))
+	assert.Equal(Paragraph{"世界世界 ab...."}, lns.PadRightWith(".", 11, RuneCount))
+}
+
+func TestGraphemeClusters(t *testing.T) {
+	assert := assert.New(t)
+	const (
+		eAcute = "e\u0301"
+		flag   = "\U0001F1EB\U0001F1F7"
+		family = "\U0001F468\u200D\U0001F469\u200D\U0001F467"
+		hangul = "\u1112\u1161\u11AB"
+	)
+	for _, g := range []string{eAcute, flag, family, hangul, "\r\n", "👍🏽", "❤️"} {
+		cluster, rest := nextGrapheme(g + "x")
+		assert.Equal(g, cluster)
+		assert.Equal("x", rest)
+	}
+	assert.Equal(2, DisplayWidth(flag))
+	assert.Equal(2, DisplayWidth(family))
+	assert.Equal(2, DisplayWidth(hangul))
+	assert.Equal(2, DisplayWidth("❤️"))
+	assert.Equal(4, DisplayWidth(flag+flag))
+
+	lns := NewFromString("caf" + eAcute + eAcute + " " + flag + flag + family)
+	assert.Equal(Paragraph{"caf" + eAcute}, lns.Cut(4))
+	assert.Equal(Paragraph{"caf" + eAcute + eAcute + " " + flag}, lns.Cut(8))
+	assert.Equal(Paragraph{"caf" + eAcute + eAcute + " " + flag}, lns.Cut(9))
+	assert.Equal(Paragraph{"caf" + eAcute + eAcute, flag + flag + family}, lns.Limit(6))
+	assert.Equal(Paragraph{flag + flag, family}, NewFromString(flag+flag+family).Limit(5))
+	assert.Equal(Paragraph{"caf" + eAcute}, lns.CutWith(6, RuneCount))
+}
+
+func TestANSI(t *testing.T) {
+	assert := assert.New(t)
+	const (
+		red   = "\x1b[31m"
+		bold  = "\x1b[1m"
+		reset = "\x1b[0m"
+		link  = "\x1b]8;;https://example.com\x1b\\"
+	)
+	assert.Equal(5, DisplayWidth(red+"hello"+reset))
+	assert.Equal(4, DisplayWidth(link+"site\x1b]8;;\a"))
+	assert.Equal("hello", StripANSI(red+"hel"+bold+"lo"+reset))
+	assert.Equal(Paragraph{"site"}, NewFromString(link+"site\x1b]8;;\a").StripANSI())
+
+	lns := NewFromString(red + "Lorem " + bold + "ipsum" + reset + " dolor")
+	assert.Equal(17, lns.Width())
+	assert.Equal(17, lns.WidthWith(RuneCount)) // the escape sequences are skipped whatever the WidthFunc
+	assert.Equal(Paragraph{red + "Lorem " + bold + "ip" + reset}, lns.Cut(8))
+	assert.Equal(Paragraph{red + "Lorem" + reset, red + bold + "ipsum" + reset, "dolor"}, lns.Limit(6))
+	assert.Equal(Paragraph{red + "Lorem " + bold + "ipsum" + reset + " dolor.."}, lns.PadRight(".", 19))
+	assert.Equal(Paragraph{"ab" + red + "-" + reset + "-"}, NewFromString("ab").PadRight(red+"-"+reset+"-", 4))
+	assert.Equal(Paragraph{"ab" + red + "-" + reset}, NewFromString("ab").PadRight(red+"-", 3))
+
+	assert.Equal("", activeSGR(red+"a"+reset))
+	assert.Equal("\x1b[1;31m", activeSGR(red+"a"+bold))
+	assert.Equal("\x1b[32m", activeSGR(red+"\x1b[0;32m"))
+	assert.Equal("\x1b[38;5;0m", activeSGR(red+"\x1b[38;5;0m"))
+	assert.Equal("", activeSGR(red+"\x1b[m"))
+	assert.Equal(red, activeSGR(red+bold+"a\x1b[22m")) // partial reset
+	assert.Equal("", activeSGR(bold+"a\x1b[22m"))
+	assert.Equal(Paragraph{bold + "bold" + "\x1b[22m plain", "words here"}, NewFromString(bold+"bold\x1b[22m plain words here").Limit(10))
+}
+
+func ExampleParagraph_Box_ansi() {
+	lns := NewFromString("\x1b[31mred\x1b[0m\nplain")
+	fmt.Println(lns.AutoBox(BoxSettings{5, "", LabelAlignLeft, "", LabelAlignLeft}, GetBoxPattern(BoxStyleSingleLine)).StripANSI())
+	//Output:
+	// ┌─────┐
+	// │red  │
+	// │plain│
+	// └─────┘
+}
+
+func TestStyle(t *testing.T) {
+	assert := assert.New(t)
+	line := StyledLine{
+		{"Error", Style{Foreground: ColorRed, Bold: true}},
+		{": ", Style{}},
+		{"disk", Style{Foreground: RGBColor(255, 135, 0), Background: ANSI256Color(236), Underline: true}},
+	}
+	assert.Equal("Error: disk", line.String())
+	assert.Equal(11, line.Width())
+	assert.Equal("Error: disk", line.Render(ColorProfileNoColor))
+	assert.Equal("\x1b[1;31mError\x1b[0m: \x1b[4;38;2;255;135;0;48;5;236mdisk\x1b[0m", line.Render(ColorProfileTrueColor))
+	assert.Equal("\x1b[1;31mError\x1b[0m: \x1b[4;38;5;208;48;5;236mdisk\x1b[0m", line.Render(ColorProfileANSI256))
+	assert.Equal("\x1b[1;31mError\x1b[0m: \x1b[4;33;40mdisk\x1b[0m", line.Render(ColorProfileANSI))
+
+	assert.Equal("\x1b[97;104mx\x1b[0m", Style{Foreground: ColorBrightWhite, Background: ColorBrightBlue}.Render("x", ColorProfileANSI))
+	assert.Equal("\x1b[3;7mx\x1b[0m", Style{Italic: true, Reverse: true}.Render("x", ColorProfileANSI))
+	assert.Equal("x", Style{}.Render("x", ColorProfileTrueColor))
+	assert.Equal(uint8(232), rgbTo256(10, 10, 10))
+	assert.Equal(uint8(231), rgbTo256(255, 255, 255))
+
+	lns := NewFromStyled([]StyledLine{line, {{"ok", Style{Foreground: ColorGreen}}}}, ColorProfileANSI)
+	assert.Equal(11, lns.Width())
+	assert.Equal(Paragraph{"Error: disk", "ok"}, lns.StripANSI())
+	assert.Equal(Paragraph{"\x1b[7mab\x1b[31mc\x1b[0m\x1b[7m\x1b[0m"}, NewFromString("ab\x1b[31mc\x1b[0m").Style(Style{Reverse: true}, ColorProfileANSI))
+	// The partial resets restore the style, while the attributes still set by the line are kept
+	blue := Style{Foreground: ColorBlue, Bold: true}
+	assert.Equal(Paragraph{"\x1b[1;34ma\x1b[31mb\x1b[39m\x1b[1;34mc\x1b[0m"}, NewFromString("a\x1b[31mb\x1b[39mc").Style(blue, ColorProfileANSI))
+	assert.Equal(Paragraph{"\x1b[1;34m\x1b[4;42ma\x1b[22m\x1b[1;4;34;42mb\x1b[0m"}, NewFromString("\x1b[4;42ma\x1b[22mb").Style(blue, ColorProfileANSI))
+	assert.Equal("\x1b[1;34mx\x1b[38;5;9my\x1b[39;49m\x1b[1;34mz\x1b[0m", blue.Render("x\x1b[38;5;9my\x1b[39;49mz", ColorProfileANSI))
+	assert.Equal("\x1b[1;34mx\x1b[38;2;1;2;3;4my\x1b[24m\x1b[1;38;2;1;2;3mz\x1b[0m", blue.Render("x\x1b[38;2;1;2;3;4my\x1b[24mz", ColorProfileANSI))
+}
+
+func ExampleBoxPattern_Styled() {
+	lns := NewFromStyled([]StyledLine{
+		{{"Status: ", Style{}}, {"OK", Style{Foreground: ColorGreen}}},
+		{{"Load: 0.42", Style{}}},
+	}, ColorProfileANSI)
+	pattern := GetBoxPattern(BoxStyleSingleLine).Styled(Style{Foreground: ColorRed}, ColorProfileANSI)
+	boxed := lns.AutoBox(BoxSettings{10, "", LabelAlignLeft, "", LabelAlignLeft}, pattern)
+	fmt.Printf("%q\n", boxed[1])
+	fmt.Println(boxed.StripANSI())
+	//Output:
+	// "\x1b[31m│\x1b[0mStatus: \x1b[32mOK\x1b[0m\x1b[31m│\x1b[0m"
+	// ┌──────────┐
+	// │Status: OK│
+	// │Load: 0.42│
+	// └──────────┘
+}
+
+// The labels are truncated on grapheme cluster boundaries, the "é" is written as e + combining acute accent.
+func ExampleParagraph_Box_label() {
+	lns := NewFromString("ab")
+	fmt.Println(lns.AutoBox(BoxSettings{2, "e\u0301tiquette", LabelAlignLeft, "\U0001F1EB\U0001F1F7\U0001F1E9\U0001F1EA", LabelAlignLeft}, GetBoxPattern(BoxStyleSingleLine)))
+	//Output:
+	// ┌ét┐
+	// │ab│
+	// └🇫🇷┘
+}
+
+func ExampleParagraph_Box_wide() {
+	lns := NewFromString("世界\nabcd")
+	fmt.Println(lns.AutoBox(BoxSettings{10, "標題", LabelAlignCenter, "", LabelAlignLeft}, GetBoxPattern(BoxStyleSingleLine)))
+	//Output:
+	// ┌標題┐
+	// │世界│
+	// │abcd│
+	// └────┘
+}
+
 func TestWriteToFile(t *testing.T) {
 	const fileName = "test.txt"
 	assert := assert.New(t)
@@ -49,9 +230,297 @@ func TestWriteToFile(t *testing.T) {
 	assert.True(compareGoldenFile(fileName))
 	os.Remove(fileName)
 
-	// ? is not a valid character for à file name
-	err := lns.WriteToFile("?")
+	// The directory doesn't exist
+	err := lns.WriteToFile(filepath.Join(t.TempDir(), "missing", fileName))
+	assert.Error(err)
+}
+
+func TestWriteFile(t *testing.T) {
+	assert := assert.New(t)
+	dir := t.TempDir()
+	fileName := filepath.Join(dir, "banner.conf")
+	readFile := func(name string) string {
+		b, _ := os.ReadFile(name)
+		return string(b)
+	}
+	files := func() (names []string) {
+		entries, _ := os.ReadDir(dir)
+		for _, e := range entries {
+			names = append(names, e.Name())
+		}
+		return
+	}
+
+	settings := FileSettings{Atomic: true, Backup: "~", Perm: 0600}
+	assert.Nil(Paragraph{"# v1"}.WriteFile(fileName, settings))
+	assert.Equal("# v1\n", readFile(fileName))
+	assert.Equal([]string{"banner.conf"}, files()) // no backup of a missing file, no temporary file left
+	info, _ := os.Stat(fileName)
+	assert.Zero(info.Mode().Perm() & 0077)
+
+	assert.Nil(os.Chmod(fileName, 0640))
+	settings.Perm = 0
+	assert.Nil(Paragraph{"# v2"}.WriteFile(fileName, settings))
+	assert.Equal("# v2\n", readFile(fileName))
+	assert.Equal("# v1\n", readFile(fileName+"~"))
+	assert.Equal([]string{"banner.conf", "banner.conf~"}, files())
+	info, _ = os.Stat(fileName)
+	assert.Equal(fs.FileMode(0640), info.Mode().Perm())
+
+	// The link is kept and its target replaced
+	link := filepath.Join(dir, "link.conf")
+	if os.Symlink(fileName, link) == nil {
+		assert.Nil(Paragraph{"# v3"}.WriteFile(link, FileSettings{Atomic: true}))
+		assert.Equal("# v3\n", readFile(fileName)) // the zero value of the settings writes a final newline, like WriteToFile
+		info, _ = os.Lstat(link)
+		assert.NotZero(info.Mode() & fs.ModeSymlink)
+		os.Remove(link)
+	}
+
+	for _, atomic := range []bool{false, true} {
+		err := Paragraph{"# v4"}.WriteFile(fileName, FileSettings{Atomic: atomic, NoOverwrite: true})
+		assert.True(errors.Is(err, fs.ErrExist))
+		assert.NotEqual("# v4\n", readFile(fileName))
+		newName := filepath.Join(dir, fmt.Sprint("new", atomic))
+		assert.Nil(Paragraph{"# v4"}.WriteFile(newName, FileSettings{Atomic: atomic, NoOverwrite: true}))
+		assert.Equal("# v4\n", readFile(newName))
+		os.Remove(newName)
+	}
+
+	// The directory doesn't exist
+	assert.Error(Paragraph{}.WriteFile(filepath.Join(dir, "missing", "banner.conf"), FileSettings{Atomic: true}))
+	assert.Equal([]string{"banner.conf", "banner.conf~"}, files())
+}
+
+func TestReadWrite(t *testing.T) {
+	assert := assert.New(t)
+	lns := linesSample2(10)
+	var buf bytes.Buffer
+	n, err := lns.WriteTo(&buf)
+	assert.Nil(err)
+	assert.Equal(int64(buf.Len()), n)
+	golden, _ := os.ReadFile(filepath.Join("testdata", "test.txt.golden"))
+	assert.Equal(golden, buf.Bytes())
+
+	var read Paragraph
+	n, err = read.ReadFrom(bytes.NewReader(golden))
+	assert.Nil(err)
+	assert.Equal(int64(len(golden)), n)
+	assert.Equal(lns, read)
+	assert.Implements((*io.WriterTo)(nil), lns)
+	assert.Implements((*io.ReaderFrom)(nil), &read)
+
+	var zipped bytes.Buffer
+	zw := gzip.NewWriter(&zipped)
+	_, err = lns.WriteTo(zw)
+	assert.Nil(err)
+	assert.Nil(zw.Close())
+	zr, err := gzip.NewReader(&zipped)
+	assert.Nil(err)
+	read, err = NewFromReader(zr, 0)
+	assert.Nil(err)
+	assert.Equal(lns, read)
+
+	read, err = NewFromReader(strings.NewReader("ab\r\ncd\nef"), 2)
+	assert.Nil(err)
+	assert.Equal(Paragraph{"ab", "cd", "ef"}, read)
+	read, err = NewFromReader(strings.NewReader("ab\nabc\n"), 2)
+	assert.ErrorIs(err, bufio.ErrTooLong)
+	assert.Equal(Paragraph{"ab"}, read)
+	_, err = NewFromReader(strings.NewReader("ab\nabcd\n"), 2)
+	assert.ErrorIs(err, bufio.ErrTooLong)
+	read, err = NewFromReader(strings.NewReader(""), 2)
+	assert.Nil(err)
+	assert.Equal(Paragraph{}, read)
+
+	fileName := filepath.Join(t.TempDir(), "test.txt")
+	assert.Nil(lns.WriteToFile(fileName))
+	read, err = ReadFromFile(fileName)
+	assert.Nil(err)
+	assert.Equal(lns, read)
+	_, err = ReadFromFile(filepath.Join(t.TempDir(), "missing.txt"))
+	assert.Error(err)
+
+	_, err = lns.WriteTo(failingWriter{})
+	assert.Error(err)
+}
+
+func TestLineEnding(t *testing.T) {
+	assert := assert.New(t)
+
+	assert.Equal(Paragraph{"a", "b", ""}, NewFromString("a\r\nb\r\n"))
+	assert.Equal(Paragraph{"a", "b", "c"}, NewFromString("a\rb\nc"))
+	assert.Equal(Paragraph{""}, NewFromString(""))
+	assert.Equal(Paragraph{"", ""}, NewFromString("\r"))
+
+	for _, s := range []string{"a\nb\n", "a\r\nb\r\n", "a\rb\r", "a\r\nb", "a\r\n\r\nb\r\n", "a", ""} {
+		lns, format := NewFromStringFormat(s)
+		assert.Equal(s, lns.ToStringFormat(format), "%q", s)
+
+		read, format, err := NewFromReaderFormat(iotest.OneByteReader(strings.NewReader(s)), 0)
+		assert.Nil(err)
+		assert.Equal(lns, read, "%q", s)
+		var buf bytes.Buffer
+		_, err = read.WriteToFormat(&buf, format)
+		assert.Nil(err)
+		assert.Equal(s, buf.String(), "%q", s)
+	}
+
+	lns, format := NewFromStringFormat("a\r\nb")
+	assert.Equal(Paragraph{"a", "b"}, lns)
+	assert.Equal(TextFormat{LineEnding: LineEndingCRLF, FinalNewline: false}, format)
+	lns, format = NewFromStringFormat("a\rb\r\nc\n")
+	assert.Equal(Paragraph{"a", "b", "c"}, lns)
+	assert.Equal(TextFormat{LineEnding: LineEndingCR, FinalNewline: true}, format)
+	assert.Equal("a\r\nb\r\nc\r\n", lns.ToStringFormat(TextFormat{LineEnding: LineEndingCRLF, FinalNewline: true}))
+
+	fileName := filepath.Join(t.TempDir(), "crlf.txt")
+	assert.Nil(Paragraph{"x", "y"}.WriteToFileFormat(fileName, TextFormat{LineEnding: LineEndingCRLF}))
+	b, _ := os.ReadFile(fileName)
+	assert.Equal("x\r\ny", string(b))
+	read, format, err := ReadFromFileFormat(fileName)
+	assert.Nil(err)
+	assert.Equal(Paragraph{"x", "y"}, read)
+	assert.Equal(TextFormat{LineEnding: LineEndingCRLF, FinalNewline: false}, format)
+
+	_, _, err = NewFromReaderFormat(strings.NewReader("abc\r\n"), 3)
+	assert.Nil(err)
+	_, _, err = NewFromReaderFormat(strings.NewReader("abcd\r\n"), 3)
+	assert.True(errors.Is(err, bufio.ErrTooLong))
+}
+
+func TestEncoding(t *testing.T) {
+	assert := assert.New(t)
+	read := func(b string, settings ReadSettings) (Paragraph, TextFormat) {
+		lns, format, err := ReadParagraph(iotest.OneByteReader(strings.NewReader(b)), settings)
+		assert.Nil(err, "%q", b)
+		return lns, format
+	}
+
+	for _, test := range []struct {
+		encoded  string
+		settings ReadSettings
+		lines    Paragraph
+		format   TextFormat
+	}{
+		{"caf\xe9\r\n\xa4", ReadSettings{Encoding: EncodingLatin1}, Paragraph{"café", "¤"}, TextFormat{LineEnding: LineEndingCRLF, FinalNewline: false, Encoding: EncodingLatin1}},
+		{"\x93\x80 5\x94\n", ReadSettings{Encoding: EncodingWindows1252}, Paragraph{"“€ 5”"}, TextFormat{FinalNewline: true, Encoding: EncodingWindows1252}},
+		{"\xef\xbb\xbfk\xc3\xa9\n", ReadSettings{}, Paragraph{"ké"}, TextFormat{FinalNewline: true, BOM: true}},
+		{"\xff\xfeh\x00\xe9\x00\r\x00\n\x00=\xd8\x00\xde", ReadSettings{Encoding: EncodingLatin1}, Paragraph{"hé", "😀"}, TextFormat{LineEnding: LineEndingCRLF, Encoding: EncodingUTF16LE, BOM: true}},
+		{"\xfe\xff\x00a\x00\n\x4e\x16\x75\x4c\x00\n", ReadSettings{}, Paragraph{"a", "世界"}, TextFormat{FinalNewline: true, Encoding: EncodingUTF16BE, BOM: true}},
+		{"a\x00\n\x00", ReadSettings{Encoding: EncodingUTF16LE}, Paragraph{"a"}, TextFormat{FinalNewline: true, Encoding: EncodingUTF16LE}},
+		{"\xef\xbb\xbf", ReadSettings{}, Paragraph{}, TextFormat{BOM: true}}, // a BOM without any line
+		{"\xff\xfe", ReadSettings{}, Paragraph{}, TextFormat{Encoding: EncodingUTF16LE, BOM: true}},
+	} {
+		lns, format := read(test.encoded, test.settings)
+		assert.Equal(test.lines, lns, "%q", test.encoded)
+		assert.Equal(test.format, format, "%q", test.encoded)
+		var buf bytes.Buffer
+		n, err := lns.WriteToFormat(&buf, format)
+		assert.Nil(err)
+		assert.Equal(int64(buf.Len()), n)
+		assert.Equal(test.encoded, buf.String())
+	}
+
+	// Invalid sequences
+	lns, _ := read("\x00\xd8a\x00\x00\xdc\x62", ReadSettings{Encoding: EncodingUTF16LE})
+	assert.Equal(Paragraph{"\ufffda\ufffd\ufffd"}, lns)
+	lns, _ = read("ok\nbad \xff\xfe!\n", ReadSettings{})
+	assert.Equal(Paragraph{"ok", "bad \xff\xfe!"}, lns)
+	err := lns.ValidateUTF8()
+	assert.True(errors.Is(err, ErrInvalidUTF8))
+	assert.Equal("Line 2, byte 4: Invalid UTF-8 sequence", err.Error())
+	assert.Nil(Paragraph{"ok", "世界"}.ValidateUTF8())
+	assert.Equal(Paragraph{"ok", "bad ?!"}, lns.ToValidUTF8("?"))
+	lns, _ = read("ok\nbad \xff\xfe!\n", ReadSettings{InvalidUTF8: InvalidUTF8Replace})
+	assert.Equal(Paragraph{"ok", "bad \ufffd!"}, lns)
+	_, _, err = ReadParagraph(strings.NewReader("ok\nbad \xff\xfe!\n"), ReadSettings{InvalidUTF8: InvalidUTF8Report})
+	assert.True(errors.Is(err, ErrInvalidUTF8))
+	assert.Equal("Unable to read line 2, byte 4: Invalid UTF-8 sequence", err.Error())
+
+	// Characters which cannot be encoded
+	var buf bytes.Buffer
+	Paragraph{"€ 世 \u0080 \xff"}.WriteToFormat(&buf, TextFormat{Encoding: EncodingLatin1})
+	assert.Equal("? ? \x80 ?", buf.String())
+	buf.Reset()
+	Paragraph{"€ 世 \u0080 \u0081"}.WriteToFormat(&buf, TextFormat{Encoding: EncodingWindows1252})
+	assert.Equal("\x80 ? ? \x81", buf.String())
+
+	fileName := filepath.Join(t.TempDir(), "legacy.txt")
+	assert.Nil(Paragraph{"Grüße"}.WriteFile(fileName, FileSettings{Format: &TextFormat{Encoding: EncodingUTF16LE, BOM: true}}))
+	lns, format, err := ReadFile(fileName, ReadSettings{})
+	assert.Nil(err)
+	assert.Equal(Paragraph{"Grüße"}, lns)
+	assert.Equal(TextFormat{Encoding: EncodingUTF16LE, BOM: true}, format)
+}
+
+func ExampleNewFromStringFormat() {
+	lns, format := NewFromStringFormat("first\r\nsecond\r\n")
+	fmt.Printf("%q %v %v\n", []string(lns), format.LineEnding, format.FinalNewline)
+	fmt.Printf("%q\n", lns.ToStringFormat(format))
+	fmt.Printf("%q\n", lns.ToStringFormat(DefaultTextFormat))
+	//Output:
+	// ["first" "second"] LineEndingCRLF true
+	// "first\r\nsecond\r\n"
+	// "first\nsecond\n"
+}
+
+func TestToString(t *testing.T) {
+	assert := assert.New(t)
+	lns := Paragraph{"Hello", "", "世界"}
+	assert.Equal("Hello|\n|\n世界|\n", lns.ToString("|\n"))
+	assert.Equal("", Paragraph{}.ToString("\n"))
+	assert.Equal("Hello\n\n世界\n", lns.String())
+	assert.Equal([]byte(">Hello, , 世界, "), lns.AppendTo([]byte(">"), ", "))
+	assert.Equal([]byte(nil), Paragraph{}.AppendTo(nil, "\n"))
+	var buf bytes.Buffer
+	n, err := lns.WriteToSeparator(&buf, "\r\n")
+	assert.Nil(err)
+	assert.Equal(int64(buf.Len()), n)
+	assert.Equal("Hello\r\n\r\n世界\r\n", buf.String())
+	_, err = lns.WriteToSeparator(failingWriter{}, "\n")
 	assert.Error(err)
+
+	// A single allocation for the string, none when the byte slice is large enough
+	large := NewWithPresetContent("Lorem Elsass ipsum gal non hoplageiss", 10000)
+	assert.Equal(1.0, testing.AllocsPerRun(10, func() { large.ToString("\n") }))
+	assert.Equal(1.0, testing.AllocsPerRun(10, func() { large.AppendTo(nil, "\n") }))
+	dst := make([]byte, 0, len(large.ToString("\n")))
+	assert.Equal(0.0, testing.AllocsPerRun(10, func() { large.AppendTo(dst, "\n") }))
+}
+
+// The time per line must not grow with the number of lines:
+// go test -run none -bench . -benchmem
+func benchmarkRendering(b *testing.B, render func(Paragraph)) {
+	for _, n := range []int{1000, 10000, 100000} {
+		lns := NewWithPresetContent("Lorem Elsass ipsum gal non hoplageiss, vielmols, jetz gehts los picon bière", n)
+		b.Run(fmt.Sprint(n), func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				render(lns)
+			}
+			b.ReportMetric(float64(b.Elapsed().Nanoseconds())/float64(b.N)/float64(n), "ns/line")
+		})
+	}
+}
+
+func BenchmarkToString(b *testing.B) {
+	benchmarkRendering(b, func(lns Paragraph) { lns.ToString("\n") })
+}
+
+func BenchmarkAppendTo(b *testing.B) {
+	var dst []byte
+	benchmarkRendering(b, func(lns Paragraph) { dst = lns.AppendTo(dst[:0], "\n") })
+}
+
+func BenchmarkWriteTo(b *testing.B) {
+	benchmarkRendering(b, func(lns Paragraph) { lns.WriteTo(io.Discard) })
+}
+
+type failingWriter struct{}
+
+func (failingWriter) Write(p []byte) (int, error) {
+	return 0, errors.New("failing writer")
 }
 
 func linesSample1() (lns Paragraph) {
@@ -789,6 +1258,10 @@ func ExampleBoxStyle() {
 	// ▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂
 	// █ BoxStyleFantasy4                       █
 	// █▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂▃▂▁▂█
+	//
+	// +----------------------------------------+
+	// | BoxStyleAscii                          |
+	// +----------------------------------------+
 }
 
 func ExampleAccoladesStyle() {
@@ -869,3 +1342,860 @@ func compareGoldenFile(fileName string) bool {
 	}
 	return bytes.Equal(got, wanted)
 }
+
+func ExampleParagraph_Wrap() {
+	lns := linesSample2(3)
+	fmt.Println(lns.Wrap(WrapSettings{Width: 24, Align: TextAlignLeft}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 24, Align: TextAlignRight}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 24, Align: TextAlignCenter}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 24, Align: TextAlignJustify}).AutoBox(BoxSettings{24, "", LabelAlignLeft, "", LabelAlignLeft}, GetBoxPattern(BoxStyleSingleLine)))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 0, Align: TextAlignJustify}))
+	//Output:
+	// |Lorem Elsass ipsum gal  |
+	// |non hoplageiss          |
+	// |vielmols, jetz gehts los|
+	// |picon bière             |
+	// |tellus eget Hans quam,  |
+	// |Christkindelsmärik      |
+	// |auctor,                 |
+	//
+	// |  Lorem Elsass ipsum gal|
+	// |          non hoplageiss|
+	// |vielmols, jetz gehts los|
+	// |             picon bière|
+	// |  tellus eget Hans quam,|
+	// |      Christkindelsmärik|
+	// |                 auctor,|
+	//
+	// | Lorem Elsass ipsum gal |
+	// |     non hoplageiss     |
+	// |vielmols, jetz gehts los|
+	// |       picon bière      |
+	// | tellus eget Hans quam, |
+	// |   Christkindelsmärik   |
+	// |         auctor,        |
+	//
+	// ┌────────────────────────┐
+	// │Lorem  Elsass  ipsum gal│
+	// │non hoplageiss          │
+	// │vielmols, jetz gehts los│
+	// │picon bière             │
+	// │tellus  eget  Hans quam,│
+	// │Christkindelsmärik      │
+	// │auctor,                 │
+	// └────────────────────────┘
+	//
+	// Lorem Elsass ipsum gal non hoplageiss
+	// vielmols, jetz gehts los picon bière
+	// tellus eget Hans quam, Christkindelsmärik auctor,
+}
+
+func ExampleWrapStrategy() {
+	lns := NewFromString("aaa bb cc ddddd")
+	fmt.Println(lns.Wrap(WrapSettings{Width: 6}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 6, Strategy: WrapStrategyOptimal}).Surround("|", "|"))
+
+	lns = linesSample2(3)
+	fmt.Println(lns.Wrap(WrapSettings{Width: 16}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 16, Strategy: WrapStrategyOptimal}).Surround("|", "|"))
+	fmt.Println(NewFromString("un porte-manteau extraordinairement long").Wrap(WrapSettings{Width: 12, Strategy: WrapStrategyOptimal}).Surround("|", "|"))
+	//Output:
+	// |aaa bb|
+	// |cc    |
+	// |ddddd |
+	//
+	// |aaa   |
+	// |bb cc |
+	// |ddddd |
+	//
+	// |Lorem Elsass    |
+	// |ipsum gal non   |
+	// |hoplageiss      |
+	// |vielmols,       |
+	// |jetz gehts los  |
+	// |picon bière     |
+	// |tellus eget Hans|
+	// |quam,           |
+	// |Christkindelsmär|
+	// |ik auctor,      |
+	//
+	// |Lorem Elsass    |
+	// |ipsum gal       |
+	// |non hoplageiss  |
+	// |vielmols,       |
+	// |jetz gehts los  |
+	// |picon bière     |
+	// |tellus eget     |
+	// |Hans quam,      |
+	// |Christkindelsmär|
+	// |ik auctor,      |
+	//
+	// |un porte-   |
+	// |manteau     |
+	// |extraordinai|
+	// |rement long |
+}
+
+func TestBreakPenalties(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("un porte-manteau")
+	settings := WrapSettings{Width: 13, Strategy: WrapStrategyOptimal}
+	assert.Equal(Paragraph{"un porte-    ", "manteau      "}, lns.Wrap(settings))
+	settings.Penalties = &BreakPenalties{Widow: 100, Hyphen: 200, Overlong: 1000}
+	assert.Equal(Paragraph{"un           ", "porte-manteau"}, lns.Wrap(settings))
+
+	lns = NewFromString("aaa bb cc d")
+	settings = WrapSettings{Width: 9, Strategy: WrapStrategyOptimal, Penalties: &BreakPenalties{Widow: 1}}
+	assert.Equal(Paragraph{"aaa bb cc", "d        "}, lns.Wrap(settings))
+	settings.Penalties.Widow = 100
+	assert.Equal(Paragraph{"aaa bb   ", "cc d     "}, lns.Wrap(settings))
+
+	assert.Equal(Paragraph{"         "}, NewFromString("").Wrap(settings))
+	settings.Penalties = &BreakPenalties{} // no penalty at all, the widow is accepted
+	assert.Equal(Paragraph{"aaa bb cc", "d        "}, lns.Wrap(settings))
+	settings.Penalties = nil
+	assert.Equal(Paragraph{"aaa bb   ", "cc d     "}, lns.Wrap(settings))
+
+	// A grapheme cluster wider than the width is kept whole, without any filler line
+	for _, settings := range []WrapSettings{{Width: 1}, {Width: 1, Strategy: WrapStrategyOptimal}, {Width: 1, Hyphenator: HyphenatorEnglish}} {
+		assert.Equal(Paragraph{"世", "界"}, NewFromString("世界").Wrap(settings))
+		assert.Equal(Paragraph{"😀"}, NewFromString("😀").Wrap(settings))
+		assert.Equal(Paragraph{"a", "😀", "b"}, NewFromString("a😀 b").Wrap(settings))
+	}
+	// No room for a hyphen at the width 1, the words are split without one
+	for _, strategy := range []WrapStrategy{WrapStrategyGreedy, WrapStrategyOptimal} {
+		assert.Equal(Paragraph{"h", "y", "p", "h", "e", "n"}, NewFromString("hyphen").Wrap(WrapSettings{Width: 1, Strategy: strategy, Hyphenator: HyphenatorEnglish}))
+		assert.Equal(Paragraph{"a", "b", "c", "d"}, NewFromString("ab\u00ADcd").Wrap(WrapSettings{Width: 1, Strategy: strategy}))
+		assert.Equal(Paragraph{"a", "b", "-", "c"}, NewFromString("ab-c").Wrap(WrapSettings{Width: 1, Strategy: strategy}))
+	}
+}
+
+func TestHyphenator(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal([]string{"hip", "po", "po", "to", "mons", "tro", "ses", "qui", "pé", "da", "lio", "pho", "bie"}, HyphenatorFrench.Hyphenate("hippopotomonstrosesquipédaliophobie"))
+	assert.Equal([]string{"«Ca", "pi", "tale»,"}, HyphenatorFrench.Hyphenate("«Capitale»,"))
+	assert.Equal([]string{"chym."}, HyphenatorFrench.Hyphenate("chym."))
+	assert.Equal([]string{"un", "rea", "son", "able"}, HyphenatorEnglish.Hyphenate("unreasonable"))
+	assert.Equal([]string{"dif", "fer", "ent"}, HyphenatorEnglish.Hyphenate("different"))
+	// The hyphenation points given by TeX with the hyph-en-us patterns
+	assert.Equal([]string{"hy", "phen", "ation"}, HyphenatorEnglish.Hyphenate("hyphenation"))
+	assert.Equal([]string{"para", "graph"}, HyphenatorEnglish.Hyphenate("paragraph"))
+	assert.Equal([]string{"ex", "traor", "di", "nary"}, HyphenatorEnglish.Hyphenate("extraordinary"))
+	assert.Equal([]string{"com", "puter"}, HyphenatorEnglish.Hyphenate("computer"))       // righthyphenmin 3
+	assert.Equal([]string{"as", "so", "ciate"}, HyphenatorEnglish.Hyphenate("associate")) // exception
+	assert.Equal([]string{"Ta", "ble"}, HyphenatorEnglish.Hyphenate("Table"))
+	assert.Equal([]string{"an", "ti", "cons", "ti", "tu", "tion", "nel", "le", "ment"}, HyphenatorFrench.Hyphenate("anticonstitutionnellement"))
+	h, err := ParseHyphenator(strings.NewReader(hyphEnUS), 2, 2)
+	assert.Nil(err)
+	assert.Equal([]string{"com", "put", "er"}, h.Hyphenate("computer"))
+	assert.Equal([]string{"well-known"}, HyphenatorEnglish.Hyphenate("well-known"))
+
+	h, err = ParseHyphenator(strings.NewReader("% patterns\n\\patterns{\n1ba 1bo\n}\n\\hyphenation{\nba-o-bab\n}\n"), 1, 1)
+	assert.NoError(err)
+	assert.Equal([]string{"ba", "ba", "bo", "ba"}, h.Hyphenate("bababoba"))
+	assert.Equal([]string{"Ba", "o", "bab"}, h.Hyphenate("Baobab"))
+	h, err = ParseHyphenator(strings.NewReader("1ba\n1bo"), 2, 2)
+	assert.NoError(err)
+	assert.Equal([]string{"ba", "ba", "bo", "ba"}, h.Hyphenate("bababoba"))
+}
+
+func ExampleHyphenator() {
+	lns := NewFromString("La hippopotomonstrosesquipédaliophobie est la peur des mots trop longs.")
+	fmt.Println(lns.Limit(14))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 14, Hyphenator: HyphenatorFrench}).Surround("|", "|"))
+	fmt.Println(lns.Wrap(WrapSettings{Width: 14, Strategy: WrapStrategyOptimal, Hyphenator: HyphenatorFrench}).Surround("|", "|"))
+	fmt.Println(NewFromString("Ein Donau\u00ADdampf\u00ADschiff\u00ADfahrts\u00ADgesellschaft").Wrap(WrapSettings{Width: 16}).Surround("|", "|"))
+	//Output:
+	// La
+	// hippopotomonst
+	// rosesquipédali
+	// ophobie est la
+	// peur des mots
+	// trop longs.
+	//
+	// |La hippopoto- |
+	// |monstrosesqui-|
+	// |pédaliophobie |
+	// |est la peur   |
+	// |des mots trop |
+	// |longs.        |
+	//
+	// |La hippopoto- |
+	// |monstrosesqui-|
+	// |pédaliophobie |
+	// |est la peur   |
+	// |des mots      |
+	// |trop longs.   |
+	//
+	// |Ein Donaudampf- |
+	// |schifffahrts-   |
+	// |gesellschaft    |
+}
+
+func ExampleParagraph_Reflow() {
+	lns := NewFromString(`Lorem Elsass ipsum gal non hoplageiss
+vielmols, jetz gehts los picon bière
+tellus eget Hans quam.
+
+- leverwurscht amet gewurztraminer
+  nüdle quam.
+- T'inquiète, ch'ai ramené du
+  schpeck,
+1. du chambon, un kuglopf
+
+    fmt.Println("code")
+    return`)
+	fmt.Println(lns.Reflow(WrapSettings{Width: 28}))
+	fmt.Println(lns.Reflow(WrapSettings{Width: 0}).Width())
+	//Output:
+	// Lorem Elsass ipsum gal non
+	// hoplageiss vielmols, jetz
+	// gehts los picon bière tellus
+	// eget Hans quam.
+	//
+	// - leverwurscht amet
+	//   gewurztraminer nüdle quam.
+	// - T'inquiète, ch'ai ramené
+	//   du schpeck,
+	// 1. du chambon, un kuglopf
+	//
+	//     fmt.Println("code")
+	//     return
+	//
+	// 37
+}
+
+func TestReflow(t *testing.T) {
+	assert := assert.New(t)
+	settings := WrapSettings{Width: 20}
+	assert.Equal(Paragraph{"para one", "    indented code"}, Paragraph{"para one", "    indented code"}.Reflow(settings))
+	assert.Equal(Paragraph{"para one continued"}, Paragraph{"para one", "   continued"}.Reflow(settings))
+	nested := Paragraph{
+		"- first item",
+		"  continued",
+		"    - nested item",
+		"      continued",
+		"        - deeper",
+		"    1. numbered",
+		"           code",
+		"",
+		"    - after a blank",
+		"\t- tabbed",
+		"- last item",
+	}
+	assert.Equal(Paragraph{
+		"- first item",
+		"  continued",
+		"    - nested item",
+		"      continued",
+		"        - deeper",
+		"    1. numbered",
+		"           code",
+		"",
+		"    - after a blank",
+		"\t- tabbed",
+		"- last item",
+	}, nested.Reflow(settings))
+	assert.Equal(Paragraph{"- first item", "  continued", "    - nested", "      item", "      continued"},
+		Paragraph{"- first item continued", "    - nested item continued"}.Reflow(WrapSettings{Width: 16}))
+	assert.Equal(Paragraph{"text", "", "    - code, not a list"}, Paragraph{"text", "", "    - code, not a list"}.Reflow(settings))
+}
+
+func ExampleParagraph_WrapPrefixed() {
+	lns := linesSample2(2)
+	fmt.Println(lns.WrapPrefixed(WrapSettings{Width: 24}, "- ", "  ").Surround("", "|"))
+	fmt.Println(lns[:1].WrapPrefixed(WrapSettings{Width: 20, Align: TextAlignJustify}, "// ", "// ").Surround("", "|"))
+	fmt.Println(lns[:1].WrapPrefixed(WrapSettings{Width: 20, Strategy: WrapStrategyOptimal}, "Note: ", "> ").Surround("", "|"))
+	//Output:
+	// - Lorem Elsass ipsum gal|
+	//   non hoplageiss|
+	// - vielmols,|
+	//   jetz gehts los|
+	//   picon bière|
+	//
+	// // Lorem      Elsass|
+	// // ipsum   gal   non|
+	// // hoplageiss|
+	//
+	// Note: Lorem Elsass|
+	// > ipsum gal|
+	// > non hoplageiss|
+}
+
+func TestWrapPrefixed(t *testing.T) {
+	assert := assert.New(t)
+	lns := linesSample2(2)
+	for _, align := range []TextAlign{TextAlignLeft, TextAlignJustify} {
+		for _, strategy := range []WrapStrategy{WrapStrategyGreedy, WrapStrategyOptimal} {
+			for _, s := range lns.WrapPrefixed(WrapSettings{Width: 20, Align: align, Strategy: strategy}, "// ", "// ") {
+				assert.Equal(strings.TrimRight(s, " "), s)
+			}
+		}
+	}
+	assert.Equal(Paragraph{"- a long", "  line"}, NewFromString("a long line").WrapPrefixed(WrapSettings{Width: 9}, "- ", "  "))
+	assert.Equal(Paragraph{"   a long", "     line"}, NewFromString("a long line").WrapPrefixed(WrapSettings{Width: 9, Align: TextAlignRight}, "", "  "))
+}
+
+func TestIndentDedent(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("  if a {\n    b()\n  }\n\n")
+	assert.Equal(Paragraph{"if a {", "  b()", "}", "", ""}, lns.Dedent())
+	assert.Equal(Paragraph{"> if a {", ">   b()", "> }", "", ""}, lns.Dedent().Indent("> "))
+	assert.Equal(Paragraph{"\tb", " c", ""}, NewFromString("\t\tb\n\t c\n ").Dedent())
+	assert.Equal(Paragraph{}, Paragraph{}.Dedent())
+	assert.Equal(lns, lns.Dedent().Indent("  "))
+}
+
+func TestPadLeftCenterDecimal(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("ab\n世界")
+	assert.Equal(Paragraph{"...ab", ".世界"}, lns.PadLeft(".", 5))
+	assert.Equal(Paragraph{"-.-ab", "-世界"}, lns.PadLeft("-.", 5))
+	assert.Equal(Paragraph{"ab", "世界"}, lns.PadLeft("", 5))
+	assert.Equal(lns, lns.PadLeft(".", MultiStringsMaxWidth+1))
+	assert.Equal(Paragraph{".ab..", "世界."}, lns.PadCenter(".", 5, CenterBiasLeft))
+	assert.Equal(Paragraph{"..ab.", ".世界"}, lns.PadCenter(".", 5, CenterBiasRight))
+	assert.Equal(Paragraph{"..世界..."}, lns[1:].PadCenterWith(".", 7, CenterBiasLeft, RuneCount))
+	assert.Equal(Paragraph{"  12.5 ", "   3.25", "1024   ", "  -1.0 "}, NewFromString("12.5\n3.25\n1024\n-1.0").PadDecimal(" ", 7, "."))
+	assert.Equal(Paragraph{"12,5 ", " 3,25"}, NewFromString("12,5\n3,25").PadDecimal(" ", 3, ","))
+	red := "\x1b[31m"
+	assert.Equal(Paragraph{red + "." + sgrReset + "ab"}, NewFromString("ab").PadLeft(red+".", 3))
+}
+
+func ExampleParagraph_PadCenter() {
+	lns := NewFromString("Lorem\nipsum dolor\nsit")
+	fmt.Println(lns.PadLeft(".", 13))
+	fmt.Println(lns.PadCenter("-=", 12, CenterBiasLeft))
+	fmt.Println(lns.PadCenter("-=", 12, CenterBiasRight))
+	fmt.Println(NewFromString("3.14159\n42\n-0.5\n1000.25").PadDecimal(" ", 12, ".").Surround("|", "|"))
+	//Output:
+	// ........Lorem
+	// ..ipsum dolor
+	// ..........sit
+	//
+	// -=-Lorem-=-=
+	// ipsum dolor-
+	// -=-=sit-=-=-
+	//
+	// -=-=Lorem-=-
+	// -ipsum dolor
+	// -=-=-sit-=-=
+	//
+	// |     3.14159|
+	// |    42      |
+	// |    -0.5    |
+	// |  1000.25   |
+}
+
+func TestJoinHorizontal(t *testing.T) {
+	assert := assert.New(t)
+	a := NewFromString("a\nbb\nccc")
+	b := NewFromString("世")
+	assert.Equal(Paragraph{"a  |世", "bb |  ", "ccc|  "}, JoinHorizontal(VerticalAlignTop, "|", a, b))
+	assert.Equal(Paragraph{"a  |  ", "bb |世", "ccc|  "}, a.JoinHorizontal(VerticalAlignMiddle, "|", b))
+	assert.Equal(Paragraph{"a  |  ", "bb |  ", "ccc|世"}, a.JoinHorizontal(VerticalAlignBottom, "|", b))
+	assert.Equal(Paragraph{"  a  ", "世bb ", "  ccc"}, JoinHorizontal(VerticalAlignMiddle, "", b, a, Paragraph{}))
+	assert.Equal(Paragraph{}, JoinHorizontal(VerticalAlignTop, "|"))
+	assert.Equal(Paragraph{"a--ccc"}, JoinHorizontal(VerticalAlignTop, "-", a[:1], Paragraph{""}, a[2:]))
+}
+
+func ExampleJoinHorizontal() {
+	chart := NewFromString("  ▃▅▇█▇▅▃\n▁▃▅▇█████").AutoBox(BoxSettings{Width: 8, TopLabel: " Chart ", TopLabelAlign: LabelAlignCenter}, GetBoxPattern(BoxStyleSingleLine))
+	legend := NewFromString("▅ sales").AutoBox(BoxSettings{Width: 7}, GetBoxPattern(BoxStyleSingleLineRounded))
+	fmt.Println(JoinHorizontal(VerticalAlignMiddle, "  ", chart, legend))
+	//Output:
+	// ┌─ Chart ─┐  ╭───────╮
+	// │  ▃▅▇█▇▅▃│  │▅ sales│
+	// │▁▃▅▇█████│  ╰───────╯
+	// └─────────┘
+}
+
+func TestHeight(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("a\nb\nc")
+	assert.Equal(Paragraph{"a", "b", "c", "", ""}, lns.PadHeight(5, VerticalAlignTop))
+	assert.Equal(Paragraph{"", "a", "b", "c", ""}, lns.PadHeight(5, VerticalAlignMiddle))
+	assert.Equal(Paragraph{"", "", "", "a", "b", "c"}, lns.PadHeight(6, VerticalAlignBottom))
+	assert.Equal(lns, lns.PadHeight(2, VerticalAlignTop))
+	assert.Equal(Paragraph{"a", "…"}, lns.TruncateHeight(2, "…"))
+	assert.Equal(Paragraph{"a", "b"}, lns.TruncateHeight(2, ""))
+	assert.Equal(Paragraph{}, lns.TruncateHeight(0, "…"))
+	assert.Equal(lns, lns.TruncateHeight(3, "…"))
+	assert.Equal(lns, lns.FitHeight(-1, VerticalAlignTop, ""))
+	assert.Equal(Paragraph{"a", "b", "c", ""}, lns.FitHeight(4, VerticalAlignMiddle, "…"))
+	assert.Equal(Paragraph{"[more]"}, lns.FitHeight(1, VerticalAlignMiddle, "[more]"))
+
+	pattern := GetBoxPattern(BoxStyleSingleLine)
+	assert.Equal(Paragraph{"┌──┐", "│a│", "│ │", "│ │", "│ │", "└──┘"}, NewFromString("a").BoxHeight(BoxSettings{Width: 2}, HeightSettings{Height: 4}, pattern))
+	assert.Equal(Paragraph{"┌──┐", "│  │", "│ab│", "│  │", "└──┘"}, NewFromString("ab").BoxHeight(BoxSettings{Width: 2}, HeightSettings{Height: 3, VerticalAlign: VerticalAlignMiddle}, pattern))
+	assert.Equal(Paragraph{"┌──┐", "│  │", "│  │", "└──┘"}, Paragraph{}.BoxHeight(BoxSettings{Width: 2}, HeightSettings{Height: 2}, pattern))
+	assert.Equal(Paragraph{"┌──┐", "│a │", "│… │", "└──┘"}, lns.PadRight(" ", 2).BoxHeight(BoxSettings{Width: 2}, HeightSettings{Height: 2, OverflowMarker: "…"}, pattern))
+	assert.Equal(Paragraph{"┌────┐", "│abcd│", "│…   │", "└────┘"}, NewFromString("abcd\nefgh\nijkl").BoxHeight(BoxSettings{Width: 4}, HeightSettings{Height: 2, OverflowMarker: "…"}, pattern))
+	assert.Equal(Paragraph{"┌──┐", "│a │", "│b │", "│c │", "└──┘"}, lns.PadRight(" ", 2).BoxHeight(BoxSettings{Width: 2}, HeightSettings{}, pattern))
+	assert.Equal(Paragraph{"┌───┐", "│a  │", "│[+]│", "└───┘"}, lns.AutoBoxHeight(BoxSettings{Width: 1}, HeightSettings{Height: 2, OverflowMarker: "[+]"}, pattern))
+	assert.Equal(lns, lns.BoxHeight(BoxSettings{Width: 0}, HeightSettings{Height: 2}, pattern))
+}
+
+func ExampleParagraph_FitHeight() {
+	pattern := GetBoxPattern(BoxStyleSingleLine)
+	cpu := NewFromString("CPU 42%").AutoBoxHeight(BoxSettings{Width: 1}, HeightSettings{Height: 3, VerticalAlign: VerticalAlignMiddle}, pattern)
+	logs := NewFromString("started\nlistening\nrequest 1\nrequest 2\nrequest 3").AutoBoxHeight(BoxSettings{Width: 1}, HeightSettings{Height: 3, OverflowMarker: "…"}, pattern)
+	fmt.Println(JoinHorizontal(VerticalAlignTop, " ", cpu, logs))
+	//Output:
+	// ┌───────┐ ┌─────────┐
+	// │       │ │started  │
+	// │CPU 42%│ │listening│
+	// │       │ │…        │
+	// └───────┘ └─────────┘
+}
+
+func TestCanvas(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(Paragraph{"    ", "    "}, NewCanvas(4, 2).Paragraph())
+	assert.Equal(Paragraph{}, NewCanvas(-1, -1).Paragraph())
+	// Clipping
+	assert.Equal(Paragraph{"cd  ", "    "}, NewCanvas(4, 2).DrawAt(Paragraph{"abcd"}, -2, 0).Paragraph())
+	assert.Equal(Paragraph{"   a", "   c"}, NewCanvas(4, 2).DrawAt(Paragraph{"x", "ab", "cd"}, 3, -1).Paragraph())
+	assert.Equal(Paragraph{" 世 "}, NewCanvas(4, 1).DrawAt(Paragraph{"世世世"}, -1, 0).Paragraph())
+	assert.Equal(Paragraph{"世 "}, NewCanvas(3, 1).DrawAt(Paragraph{"世世"}, 0, 0).Paragraph())
+	// Z-order and transparency
+	canvas := NewCanvas(5, 1)
+	canvas.Draw(Paragraph{"a b c"}, DrawSettings{Z: 1, Transparent: " "})
+	canvas.Draw(Paragraph{"12345"}, DrawSettings{})
+	assert.Equal(Paragraph{"a2b4c"}, canvas.Paragraph())
+	canvas.Draw(Paragraph{"xx"}, DrawSettings{X: 3, Z: 1})
+	assert.Equal(Paragraph{"a2bxx"}, canvas.Paragraph())
+	// Overwritten halves of wide characters
+	assert.Equal(Paragraph{" x世"}, NewCanvas(4, 1).DrawAt(Paragraph{"世世"}, 0, 0).DrawAt(Paragraph{"x"}, 1, 0).Paragraph())
+	assert.Equal(Paragraph{"x 世"}, NewCanvas(4, 1).DrawAt(Paragraph{"世世"}, 0, 0).DrawAt(Paragraph{"x"}, 0, 0).Paragraph())
+	assert.Equal(Paragraph{" 界 "}, NewCanvas(4, 1).DrawAt(Paragraph{"世世"}, 0, 0).DrawAt(Paragraph{"界"}, 1, 0).Paragraph())
+	// Styles
+	red := "\x1b[31m"
+	assert.Equal(Paragraph{"a" + red + "bc" + sgrReset + "d"}, NewCanvas(4, 1).DrawAt(Paragraph{"abcd"}, 0, 0).DrawAt(Paragraph{red + "bc" + sgrReset}, 1, 0).Paragraph())
+	assert.Equal(Paragraph{red + "c" + sgrReset + "  "}, NewCanvas(3, 1).DrawAt(Paragraph{red + "abc"}, -2, 0).Paragraph())
+}
+
+func ExampleCanvas() {
+	pattern := GetBoxPattern(BoxStyleSingleLine)
+	canvas := NewCanvas(24, 6)
+	canvas.DrawAt(NewFromString("Lorem Elsass ipsum\nhopla kougelhopf\nsalu bredele").AutoBox(BoxSettings{Width: 1}, pattern), 0, 0)
+	canvas.Draw(NewFromString("Popup").AutoBox(BoxSettings{Width: 1}, GetBoxPattern(BoxStyleDoubleLine)), DrawSettings{X: 12, Y: 2, Z: 1})
+	canvas.Draw(NewFromString("*  *"), DrawSettings{X: 2, Y: 5, Transparent: " "})
+	fmt.Println(canvas.Paragraph().Surround("|", "|"))
+	//Output:
+	// |┌──────────────────┐    |
+	// |│Lorem Elsass ipsum│    |
+	// |│hopla kouge╔═════╗│    |
+	// |│salu bredel║Popup║│    |
+	// |└───────────╚═════╝┘    |
+	// |  *  *                  |
+}
+
+func TestMergeLineArt(t *testing.T) {
+	assert := assert.New(t)
+	for _, c := range [][3]string{
+		{"┐", "┌", "┬"}, {"┘", "└", "┴"}, {"┐", "┘", "┤"}, {"└", "┌", "├"}, {"─", "│", "┼"}, {"┬", "┴", "┼"},
+		{"┓", "┏", "┳"}, {"╗", "╔", "╦"}, {"╝", "╚", "╩"}, {"═", "│", "╪"}, {"╮", "╭", "┬"}, {"─", "╭", "┬"},
+		{"╭", "┌", "┌"}, {"┌", "╭", "╭"}, {"─", "─", "─"}, {"━", "│", "┿"}, {"┼", "╔", "╬"}, {"┃", "═", "╬"},
+		{"x", "┌", ""}, {"┌", "x", ""}, {"┌┐", "┌", ""},
+		{"+", "-", "+"}, {"-", "|", "+"}, {"-", "-", "-"}, {"-", "─", ""},
+	} {
+		assert.Equal(c[2], mergeLineArt(c[0], c[1]), c[0]+c[1])
+	}
+	assert.Len(lineArtRunes, len(lineArt)-4)
+}
+
+func ExampleDrawSettings_mergeLines() {
+	settings := BoxSettings{Width: 1}
+	cell := func(s string, style BoxStyle) Paragraph {
+		return NewFromString(s).PadRight(" ", 5).AutoBox(settings, GetBoxPattern(style))
+	}
+	canvas := NewCanvas(19, 5)
+	canvas.Draw(cell("Name", BoxStyleSingleLine), DrawSettings{MergeLines: true})
+	canvas.Draw(cell("Qty", BoxStyleSingleLine), DrawSettings{X: 6, MergeLines: true})
+	canvas.Draw(cell("Price", BoxStyleSingleLine), DrawSettings{X: 12, MergeLines: true})
+	canvas.Draw(cell("ab", BoxStyleSingleLine), DrawSettings{Y: 2, MergeLines: true})
+	canvas.Draw(cell("3", BoxStyleBold), DrawSettings{X: 6, Y: 2, MergeLines: true})
+	canvas.Draw(cell("1.5", BoxStyleDoubleLine), DrawSettings{X: 12, Y: 2, MergeLines: true})
+	fmt.Println(canvas.Paragraph())
+	//Output:
+	// ┌─────┬─────┬─────┐
+	// │Name │Qty  │Price│
+	// ├─────╆━━━━━╬═════╣
+	// │ab   ┃3    ║1.5  ║
+	// └─────┺━━━━━╩═════╝
+}
+
+func ExampleTable() {
+	table := Table{
+		Header: NewTableRow("Name", "Qty", "Price"),
+		Rows: [][]TableCell{
+			NewTableRow("Bredele", "12", "3.50"),
+			NewTableRow("Kougelhopf\n(large)", "1", "14.90"),
+			{{Lines: NewFromString("Total"), Span: 2}, NewTableCell("56.90")},
+		},
+	}
+	fmt.Println(table.Render(TableSettings{Style: BoxStyleSingleLine, HeaderStyle: BoxStyleSingleVDoubleH, Align: []TextAlign{TextAlignLeft, TextAlignRight, TextAlignRight}}))
+	fmt.Println(table.Render(TableSettings{Style: BoxStyleSingleLineRounded, MaxColumnWidth: 6}))
+	fmt.Println(table.Render(TableSettings{Style: BoxStyleAscii, Align: []TextAlign{TextAlignCenter}}))
+	//Output:
+	// ╒══════════╤═══╤═════╕
+	// │Name      │Qty│Price│
+	// ╞══════════╪═══╪═════╡
+	// │Bredele   │ 12│ 3.50│
+	// ├──────────┼───┼─────┤
+	// │Kougelhopf│  1│14.90│
+	// │(large)   │   │     │
+	// ├──────────┴───┼─────┤
+	// │Total         │56.90│
+	// └──────────────┴─────┘
+	//
+	// ╭──────┬───┬─────╮
+	// │Name  │Qty│Price│
+	// ├──────┼───┼─────┤
+	// │Bredel│12 │3.50 │
+	// │e     │   │     │
+	// ├──────┼───┼─────┤
+	// │Kougel│1  │14.90│
+	// │hopf  │   │     │
+	// │(large│   │     │
+	// │)     │   │     │
+	// ├──────┴───┼─────┤
+	// │Total     │56.90│
+	// ╰──────────┴─────╯
+	//
+	// +----------+---+-----+
+	// |   Name   |Qty|Price|
+	// +----------+---+-----+
+	// |  Bredele |12 |3.50 |
+	// +----------+---+-----+
+	// |Kougelhopf|1  |14.90|
+	// |  (large) |   |     |
+	// +----------+---+-----+
+	// |     Total    |56.90|
+	// +--------------+-----+
+}
+
+func TestTable(t *testing.T) {
+	assert := assert.New(t)
+	assert.Equal(Paragraph{}, Table{}.Render(TableSettings{}))
+	table := Table{Rows: [][]TableCell{NewTableRow("a", "b", "c"), {{Lines: NewFromString("wide cell"), Span: 3}}, NewTableRow("d")}}
+	assert.Equal(Paragraph{
+		"┌───┬──┬──┐",
+		"│a  │b │c │",
+		"├───┴──┴──┤",
+		"│wide cell│",
+		"├───┬──┬──┤",
+		"│d  │  │  │",
+		"└───┴──┴──┘",
+	}, table.Render(TableSettings{Style: BoxStyleSingleLine}))
+	assert.Equal(Paragraph{
+		"      ",
+		" ab x ",
+		" cd   ",
+		"      ",
+	}, Table{Rows: [][]TableCell{NewTableRow("abcd", "x")}}.Render(TableSettings{MaxColumnWidth: 2, Align: []TextAlign{TextAlignLeft, TextAlignCenter}}))
+	assert.Equal(Paragraph{
+		"┌──┬──┐",
+		"│世│😀│",
+		"│界│x │",
+		"└──┴──┘",
+	}, Table{Rows: [][]TableCell{NewTableRow("世界", "😀x")}}.Render(TableSettings{Style: BoxStyleSingleLine, MaxColumnWidth: 1}))
+}
+
+func TestCSV(t *testing.T) {
+	assert := assert.New(t)
+	const data = "name,qty,comment\r\nBredele,12,\"sweet, \"\"crispy\"\"\"\r\nKougelhopf,1,\"two\nlines\"\r\n"
+	table, err := ReadCSV(strings.NewReader(data), CSVSettings{})
+	assert.Nil(err)
+	assert.Equal(NewTableRow("name", "qty", "comment"), table.Header)
+	assert.Equal([][]TableCell{NewTableRow("Bredele", "12", `sweet, "crispy"`), NewTableRow("Kougelhopf", "1", "two\nlines")}, table.Rows)
+	var sb strings.Builder
+	assert.Nil(table.WriteCSV(&sb, CSVSettings{}))
+	assert.Equal(strings.ReplaceAll(data, "\r\n", "\n"), sb.String())
+
+	table, err = ReadCSV(strings.NewReader("a\tb\nc\td\te\n"), CSVSettings{Delimiter: '\t'})
+	assert.Nil(err)
+	assert.Nil(table.Header)
+	assert.Equal([][]TableCell{NewTableRow("a", "b"), NewTableRow("c", "d", "e")}, table.Rows)
+	table, err = ReadCSV(strings.NewReader("a\tb\nc\td\n"), CSVSettings{Delimiter: '\t', Header: CSVHeaderPresent})
+	assert.Nil(err)
+	assert.Equal(NewTableRow("a", "b"), table.Header)
+	sb.Reset()
+	assert.Nil(table.WriteCSV(&sb, CSVSettings{Delimiter: ';', Header: CSVHeaderAbsent}))
+	assert.Equal("c;d\n", sb.String())
+	sb.Reset()
+	assert.Nil(Table{Rows: [][]TableCell{{{Lines: Paragraph{"x"}, Span: 2}, NewTableCell("y")}}}.WriteCSV(&sb, CSVSettings{}))
+	assert.Equal("x,,y\n", sb.String())
+
+	table, err = ReadCSV(strings.NewReader("1,2\n3,4\n"), CSVSettings{})
+	assert.Nil(err)
+	assert.Nil(table.Header)
+	_, err = ReadCSV(strings.NewReader("a,\"b\n"), CSVSettings{})
+	assert.Error(err)
+	_, err = NewFromCSV(strings.NewReader("a,\"b\n"), CSVSettings{}, TableSettings{})
+	assert.Error(err)
+}
+
+func ExampleNewFromCSV() {
+	const data = `city,population,area
+Strasbourg,291313,78.26
+Colmar,67730,66.57
+"Mulhouse
+(Haut-Rhin)",105049,22.18
+`
+	lns, _ := NewFromCSV(strings.NewReader(data), CSVSettings{}, TableSettings{Style: BoxStyleSingleLineRounded, Align: []TextAlign{TextAlignLeft, TextAlignRight, TextAlignRight}})
+	fmt.Println(lns)
+	//Output:
+	// ╭───────────┬──────────┬─────╮
+	// │city       │population│ area│
+	// ├───────────┼──────────┼─────┤
+	// │Strasbourg │    291313│78.26│
+	// ├───────────┼──────────┼─────┤
+	// │Colmar     │     67730│66.57│
+	// ├───────────┼──────────┼─────┤
+	// │Mulhouse   │    105049│22.18│
+	// │(Haut-Rhin)│          │     │
+	// ╰───────────┴──────────┴─────╯
+}
+
+func TestTableDoc(t *testing.T) {
+	assert := assert.New(t)
+	table := Table{
+		Header: NewTableRow("a|b", "c"),
+		Rows:   [][]TableCell{NewTableRow("x\ny"), {{Lines: Paragraph{"span"}, Span: 2}}},
+	}
+	assert.Equal(Paragraph{
+		"| a\\|b   | c   |",
+		"| :----- | --- |",
+		"| x<br>y |     |",
+		"| span   |     |",
+	}, table.Markdown(TableSettings{Align: []TextAlign{TextAlignLeft}}))
+	assert.Equal(Paragraph{"| a   | b   |", "| --: | :-: |", "| 1   |     |"},
+		Table{Header: NewTableRow("a", "b"), Rows: [][]TableCell{NewTableRow("1")}}.Markdown(TableSettings{Align: []TextAlign{TextAlignRight, TextAlignCenter}}))
+	assert.Equal(Paragraph{"|     |", "| --- |", "| 1   |"}, Table{Rows: [][]TableCell{NewTableRow("1")}}.Markdown(TableSettings{}))
+	assert.Equal(Paragraph{}, Table{}.Markdown(TableSettings{}))
+	assert.Equal(Paragraph{
+		"+------+---+",
+		"| a\\|b | c |",
+		"+======+===+",
+		"| x    |   |",
+		"| y    |   |",
+		"+------+---+",
+		"| span     |",
+		"+----------+",
+	}, table.RST(TableSettings{}))
+	assert.Equal(Paragraph{
+		`[cols="<,>",options="header"]`,
+		"|===",
+		"|a\\|b |c",
+		"",
+		"|x +",
+		"y |",
+		"2+|span",
+		"|===",
+	}, table.AsciiDoc(TableSettings{Align: []TextAlign{TextAlignJustify, TextAlignRight}}))
+}
+
+func ExampleTable_Markdown() {
+	table := Table{
+		Header: NewTableRow("Function", "Output"),
+		Rows: [][]TableCell{
+			NewTableRow("Render", "terminal"),
+			NewTableRow("Markdown", "pipe | table"),
+			NewTableRow("RST", "grid table"),
+		},
+	}
+	settings := TableSettings{Align: []TextAlign{TextAlignLeft, TextAlignCenter}}
+	fmt.Println(table.Markdown(settings))
+	fmt.Println(table.RST(settings))
+	fmt.Println(table.AsciiDoc(settings))
+	//Output:
+	// | Function | Output        |
+	// | :------- | :-----------: |
+	// | Render   | terminal      |
+	// | Markdown | pipe \| table |
+	// | RST      | grid table    |
+	//
+	// +----------+---------------+
+	// | Function |     Output    |
+	// +==========+===============+
+	// | Render   |    terminal   |
+	// +----------+---------------+
+	// | Markdown | pipe \| table |
+	// +----------+---------------+
+	// | RST      |   grid table  |
+	// +----------+---------------+
+	//
+	// [cols="<,^",options="header"]
+	// |===
+	// |Function |Output
+	//
+	// |Render |terminal
+	// |Markdown |pipe \| table
+	// |RST |grid table
+	// |===
+}
+
+func TestAlignOn(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("# settings\nname = paragraph\n  width=30\n\n[section]\nlabel = a = b")
+	assert.Equal(Paragraph{"# settings", "name    = paragraph", "  width = 30", "", "[section]", "label   = a = b"},
+		lns.AlignOn(AlignOnSettings{Delimiter: "=", Fields: 2, CommentPrefix: "#"}))
+	assert.Equal(Paragraph{"# settings", "name    = paragraph", "  width = 30", "", "[section]", "label   = a         = b"},
+		lns.AlignOn(AlignOnSettings{Delimiter: "=", CommentPrefix: "#"}))
+	lns = NewFromString("apple 3 1.5 \n世界 12 10\nkiwi 100 0.25")
+	assert.Equal(Paragraph{"apple 3   1.5", "世界  12  10", "kiwi  100 0.25"}, lns.AlignOn(AlignOnSettings{Pattern: regexp.MustCompile(`\s+`)}))
+	assert.Equal(Paragraph{"apple |   3 |  1.5", "世界  |  12 |   10", "kiwi  | 100 | 0.25"},
+		lns.AlignOn(AlignOnSettings{Pattern: regexp.MustCompile(` +`), Separator: " | ", RightAlignNumbers: true}))
+	assert.Equal(lns, lns.AlignOn(AlignOnSettings{}))
+}
+
+func ExampleParagraph_AlignOn() {
+	lns := NewFromString("; Elsass.ini\ncity = Strasbourg\nriver = Ill\npopulation = 291313")
+	fmt.Println(lns.AlignOn(AlignOnSettings{Delimiter: "=", Fields: 2, CommentPrefix: ";"}))
+	lns = NewFromString("bredele 12 3.5\nkougelhopf 1 14.9\nmannele 150 0.8")
+	fmt.Println(lns.AlignOn(AlignOnSettings{Pattern: regexp.MustCompile(`\s+`), RightAlignNumbers: true}))
+	//Output:
+	// ; Elsass.ini
+	// city       = Strasbourg
+	// river      = Ill
+	// population = 291313
+	//
+	// bredele     12  3.5
+	// kougelhopf   1 14.9
+	// mannele    150  0.8
+}
+
+func TestColumns(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("a\nb\nc\nd\ne")
+	assert.Equal(Paragraph{"a  d ", "b  e ", "c    "}, lns.Columns(ColumnsSettings{Count: 2, Width: 5, Gutter: " "}))
+	assert.Equal(Paragraph{"a  b ", "c  d ", "e    "}, lns.Columns(ColumnsSettings{Count: 2, Width: 5, Gutter: " ", Order: FillOrderRowMajor}))
+	assert.Equal(Paragraph{"a │c │e", "b │d │ "}, lns.Columns(ColumnsSettings{Count: 3, Width: 7, Rule: BoxStyleSingleLine}))
+	assert.Equal(Paragraph{"ab|d ", "c |  "}, NewFromString("abc\nd").Columns(ColumnsSettings{Count: 2, Width: 5, Rule: BoxStyleAscii}))
+	assert.Equal(lns, lns.Columns(ColumnsSettings{Count: 3, Width: 3, Gutter: " "}))
+	assert.Equal(lns, lns.Columns(ColumnsSettings{Width: 10}))
+	assert.Equal(Paragraph{}, Paragraph{}.Columns(ColumnsSettings{Count: 2, Width: 10}))
+	assert.Equal(Paragraph{"世a b", "界   "}, NewFromString("世界\nab").Columns(ColumnsSettings{Count: 3, Width: 5}))
+	// The columns are balanced when the line count is not a multiple of the column count
+	assert.Equal(Paragraph{"a c d", "b    "}, NewFromString("a\nb\nc\nd").Columns(ColumnsSettings{Count: 3, Width: 5, Gutter: " "}))
+	assert.Equal(Paragraph{"a c e f", "b d    "}, NewFromString("a\nb\nc\nd\ne\nf").Columns(ColumnsSettings{Count: 4, Width: 7, Gutter: " "}))
+	assert.Equal(Paragraph{"a d f", "b e g", "c    "}, NewFromString("a\nb\nc\nd\ne\nf\ng").Columns(ColumnsSettings{Count: 3, Width: 5, Gutter: " "}))
+	assert.Equal(Paragraph{"😀|世", "x| "}, NewFromString("😀x\n世").Columns(ColumnsSettings{Count: 2, Width: 3, Rule: BoxStyleAscii}))
+}
+
+func ExampleParagraph_Columns() {
+	lns := New(BoxStyleCount)
+	for i := 0; i < BoxStyleCount; i++ {
+		lns = append(lns, strings.TrimPrefix(BoxStyle(i).String(), "BoxStyle"))
+	}
+	fmt.Println(lns.Columns(ColumnsSettings{Count: 3, Width: 60, Gutter: " ", Rule: BoxStyleSingleLine}).Surround("", "│"))
+	//Output:
+	// None               │ ExtraBold          │ Dots              │
+	// SpaceChar          │ ExtraBoldRounded   │ Diamonds          │
+	// SingleLine         │ MaxBold            │ Fantasy1          │
+	// SingleLineRounded  │ BlocksLightShade   │ Fantasy2          │
+	// Bold               │ BlocksMediumShade  │ Fantasy3          │
+	// SingleVDoubleH     │ BlocksDarkShade    │ Fantasy4          │
+	// SingleHDoubleV     │ Blocks             │ Ascii             │
+	// DoubleLine         │                    │                   │
+}
+
+func TestPaginate(t *testing.T) {
+	assert := assert.New(t)
+	lns := NewFromString("a1\na2\na3\n\nb1\nb2\nb3\nb4\n\nc1")
+	pages, err := lns.Paginate(PageSettings{Height: 4})
+	assert.Nil(err)
+	assert.Equal([]Paragraph{{"a1", "a2", "a3", ""}, {"b1", "b2", "b3", "b4"}, {"c1", "", "", ""}}, pages)
+	pages, err = lns.Paginate(PageSettings{Height: 6})
+	assert.Nil(err)
+	assert.Equal([]Paragraph{{"a1", "a2", "a3", "", "b1", "b2"}, {"b3", "b4", "", "c1", "", ""}}, pages)
+	// b4 would be a widow
+	pages, err = lns.Paginate(PageSettings{Height: 7})
+	assert.Nil(err)
+	assert.Equal([]Paragraph{{"a1", "a2", "a3", "", "b1", "b2", ""}, {"b3", "b4", "", "c1", "", "", ""}}, pages)
+	pages, err = lns.Paginate(PageSettings{Height: 7, Widows: 1})
+	assert.Nil(err)
+	assert.Equal(Paragraph{"a1", "a2", "a3", "", "b1", "b2", "b3"}, pages[0])
+	// b1 would be an orphan
+	pages, err = lns.Paginate(PageSettings{Height: 5})
+	assert.Nil(err)
+	assert.Equal(Paragraph{"a1", "a2", "a3", "", ""}, pages[0])
+	pages, err = lns.Paginate(PageSettings{Height: 5, Orphans: 1})
+	assert.Nil(err)
+	assert.Equal(Paragraph{"a1", "a2", "a3", "", "b1"}, pages[0])
+	// Lines that can't be kept together
+	pages, err = NewFromString("1\n2\n3").Paginate(PageSettings{Height: 1})
+	assert.Nil(err)
+	assert.Equal([]Paragraph{{"1"}, {"2"}, {"3"}}, pages)
+	pages, err = Paragraph{}.Paginate(PageSettings{Height: 2, Footer: Paragraph{"{{page}}/{{pages}}"}})
+	assert.Nil(err)
+	assert.Equal([]Paragraph{{"", "1/1"}}, pages)
+
+	_, err = lns.Paginate(PageSettings{Height: 2, Header: Paragraph{"h"}, Footer: Paragraph{"f"}})
+	assert.Error(err)
+	_, err = lns.Paginate(PageSettings{Height: 5, Header: Paragraph{"{{#page}}"}})
+	assert.Error(err)
+}
+
+func ExampleParagraph_Paginate() {
+	lns := linesSample2(4).Append(Paragraph{""}).Append(linesSample2(9)[4:]).Reflow(WrapSettings{Width: 28})
+	pages, _ := lns.Paginate(PageSettings{
+		Height:    9,
+		Header:    Paragraph{"{{title}}", ""},
+		Footer:    Paragraph{"", "Page {{page}} of {{pages}}"},
+		Variables: map[string]interface{}{"title": "Lorem Elsass"},
+	})
+	for _, page := range pages {
+		fmt.Println(page.PadCenter(" ", 30, CenterBiasLeft).Box(BoxSettings{Width: 30}, GetBoxPattern(BoxStyleSingleLine)))
+	}
+	//Output:
+	// ┌──────────────────────────────┐
+	// │         Lorem Elsass         │
+	// │                              │
+	// │  Lorem Elsass ipsum gal non  │
+	// │     hoplageiss vielmols,     │
+	// │  jetz gehts los picon bière  │
+	// │    tellus eget Hans quam,    │
+	// │  Christkindelsmärik auctor,  │
+	// │                              │
+	// │         Page 1 of 4          │
+	// └──────────────────────────────┘
+	//
+	// ┌──────────────────────────────┐
+	// │         Lorem Elsass         │
+	// │                              │
+	// │      leverwurscht amet       │
+	// │  gewurztraminer nüdle quam.  │
+	// │                              │
+	// │ T'inquiète, ch'ai ramené du  │
+	// │   schpeck, du chambon, un    │
+	// │                              │
+	// │         Page 2 of 4          │
+	// └──────────────────────────────┘
+	//
+	// ┌──────────────────────────────┐
+	// │         Lorem Elsass         │
+	// │                              │
+	// │  kuglopf et du schnaps dans  │
+	// │     mon rucksack. Allez,     │
+	// │ s'guelt ! Wotch a kofee avec │
+	// │    ton bibalaekaess et ta    │
+	// │ wurscht ? Yeuh non che suis  │
+	// │                              │
+	// │         Page 3 of 4          │
+	// └──────────────────────────────┘
+	//
+	// ┌──────────────────────────────┐
+	// │         Lorem Elsass         │
+	// │                              │
+	// │ au réchime, je ne mange plus │
+	// │  que des Grumbeere light et  │
+	// │     che fais de la chym.     │
+	// │                              │
+	// │                              │
+	// │                              │
+	// │         Page 4 of 4          │
+	// └──────────────────────────────┘
+}
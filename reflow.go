@@ -0,0 +1,110 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// Reflow joins the consecutive lines of the Paragraph slice into logical paragraphs, then wraps them to a new width.
+// Text hard-wrapped at a given width can this way be wrapped again at another one.
+// - Blank lines separate the paragraphs and are kept.
+// - A line starting with a list marker ("- ", "* ", "+ ", "1. ", "1) ") starts a new paragraph,
+// whose continuation lines are indented to the text after the marker. The markers can be indented, for nested lists.
+// - Lines indented with at least four spaces (or a tab) more than the text of the paragraph they follow,
+// or at least four spaces outside of a paragraph, are a code block, kept unchanged.
+// An indented list marker following a blank line still continues a list.
+// The trailing spaces of the wrapped lines are removed.
+// - settings holds the width, the alignment and the line breaking strategy, as for Wrap.
+func (linesIn Paragraph) Reflow(settings WrapSettings) (linesOut Paragraph) {
+	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	linesOut = New(len(linesIn))
+	var text []string
+	var prefix string
+	column := 0     // column of the text of the current paragraph
+	inList := false // the last paragraph is a list item
+	flush := func() {
+		if len(text) == 0 {
+			return
+		}
+		linesOut = appendReflowed(linesOut, strings.Join(text, " "), prefix, settings)
+		text = text[:0]
+	}
+	for _, s := range linesIn {
+		indent, marker := indentWidth(s), listMarker(s)
+		switch {
+		case isBlank(s):
+			flush()
+			linesOut = append(linesOut, "")
+		case len(text) > 0 && indent >= column+4, len(text) == 0 && indent >= 4 && (!inList || marker == ""):
+			flush()
+			linesOut = append(linesOut, s)
+		case marker != "":
+			flush()
+			prefix, inList = marker, true
+			column = indentWidth(prefix) + DisplayWidth(strings.TrimLeft(prefix, " \t"))
+			text = append(text, strings.TrimSpace(s[len(marker):]))
+		default:
+			if len(text) == 0 {
+				prefix, inList = s[:len(s)-len(strings.TrimLeft(s, " \t"))], false
+				column = indent
+			}
+			text = append(text, strings.TrimSpace(s))
+		}
+	}
+	flush()
+	return
+}
+
+// appendReflowed appends to linesOut the lines of a logical paragraph wrapped within the width.
+// The first line starts with prefix, the next ones with as many spaces as its width.
+func appendReflowed(linesOut Paragraph, s string, prefix string, settings WrapSettings) Paragraph {
+	first := len(linesOut)
+	linesOut = appendPrefixed(linesOut, s, prefix, strings.Repeat(" ", DisplayWidth(prefix)), settings)
+	for i := first; i < len(linesOut); i++ {
+		linesOut[i] = strings.TrimRight(linesOut[i], " ")
+	}
+	return linesOut
+}
+
+// indentWidth returns the width of the indentation of a line, a tab advancing to the next multiple of four.
+func indentWidth(s string) (width int) {
+	for _, r := range s {
+		switch r {
+		case ' ':
+			width++
+		case '\t':
+			width += 4 - width%4
+		default:
+			return
+		}
+	}
+	return
+}
+
+// listMarker returns the list marker starting the line, with its indentation and the following space,
+// or "" if the line is not a list item.
+func listMarker(s string) string {
+	indent := len(s) - len(strings.TrimLeft(s, " \t"))
+	rest := s[indent:]
+	n := 0
+	switch {
+	case strings.HasPrefix(rest, "- "), strings.HasPrefix(rest, "* "), strings.HasPrefix(rest, "+ "):
+		n = 1
+	default:
+		for n < len(rest) && n < 9 && rest[n] >= '0' && rest[n] <= '9' {
+			n++
+		}
+		if n == 0 || n >= len(rest) || (rest[n] != '.' && rest[n] != ')') {
+			return ""
+		}
+		n++
+	}
+	if n >= len(rest) || rest[n] != ' ' {
+		return ""
+	}
+	for n < len(rest) && rest[n] == ' ' {
+		n++
+	}
+	return s[:indent+n]
+}
@@ -0,0 +1,327 @@
+package paragraph
+
+import (
+	"strconv"
+	"strings"
+)
+
+type colorMode uint8
+
+const (
+	colorDefault colorMode = iota
+	color16
+	color256
+	colorRGB
+)
+
+// Color is a terminal color. The zero value is the default color of the terminal.
+type Color struct {
+	mode  colorMode
+	value uint32
+}
+
+// ANSIColor returns one of the 16 standard terminal colors (0-7 normal, 8-15 bright).
+func ANSIColor(index uint8) Color {
+	return Color{color16, uint32(index % 16)}
+}
+
+// ANSI256Color returns one of the 256 colors of the xterm palette.
+func ANSI256Color(index uint8) Color {
+	return Color{color256, uint32(index)}
+}
+
+// RGBColor returns a 24-bit color.
+func RGBColor(r uint8, g uint8, b uint8) Color {
+	return Color{colorRGB, uint32(r)<<16 | uint32(g)<<8 | uint32(b)}
+}
+
+var (
+	ColorDefault       = Color{}
+	ColorBlack         = ANSIColor(0)
+	ColorRed           = ANSIColor(1)
+	ColorGreen         = ANSIColor(2)
+	ColorYellow        = ANSIColor(3)
+	ColorBlue          = ANSIColor(4)
+	ColorMagenta       = ANSIColor(5)
+	ColorCyan          = ANSIColor(6)
+	ColorWhite         = ANSIColor(7)
+	ColorBrightBlack   = ANSIColor(8)
+	ColorBrightRed     = ANSIColor(9)
+	ColorBrightGreen   = ANSIColor(10)
+	ColorBrightYellow  = ANSIColor(11)
+	ColorBrightBlue    = ANSIColor(12)
+	ColorBrightMagenta = ANSIColor(13)
+	ColorBrightCyan    = ANSIColor(14)
+	ColorBrightWhite   = ANSIColor(15)
+)
+
+// Style describes how a text is displayed in a terminal.
+// Style{} is the default style and is rendered without any escape sequence.
+type Style struct {
+	Foreground Color
+	Background Color
+	Bold       bool
+	Italic     bool
+	Underline  bool
+	Reverse    bool
+}
+
+// Span is a run of text displayed with a single style.
+type Span struct {
+	Text  string
+	Style Style
+}
+
+// StyledLine is a line of text made of styled spans.
+type StyledLine []Span
+
+// NewFromStyled creates and returns a new Paragraph slice from styled lines rendered with a given color profile.
+// - lines are the styled lines from which to create the new Paragraph slice.
+// - profile is the color profile of the terminal on which the Paragraph will be displayed.
+func NewFromStyled(lines []StyledLine, profile ColorProfile) (linesOut Paragraph) {
+	linesOut = NewWithGivenLen(len(lines))
+	for i, line := range lines {
+		linesOut[i] = line.Render(profile)
+	}
+	return
+}
+
+// String returns the plain text of the styled line.
+func (line StyledLine) String() string {
+	var sb strings.Builder
+	for _, span := range line {
+		sb.WriteString(span.Text)
+	}
+	return sb.String()
+}
+
+// Width returns the display width of the styled line.
+func (line StyledLine) Width() int {
+	return DisplayWidth(line.String())
+}
+
+// Render returns the styled line with the escape sequences of the given color profile.
+// Each span is closed, so that its style doesn't bleed on what follows.
+func (line StyledLine) Render(profile ColorProfile) string {
+	var sb strings.Builder
+	for _, span := range line {
+		sb.WriteString(span.Style.Render(span.Text, profile))
+	}
+	return sb.String()
+}
+
+// Render returns the text surrounded by the escape sequences of the style for the given color profile.
+// The style is restored after each reset found in the text, full (ESC[0m) or partial (ESC[39m, ESC[22m...).
+// The colors the profile doesn't support are replaced by the nearest supported ones,
+// and the text is returned unchanged with ColorProfileNoColor.
+func (style Style) Render(text string, profile ColorProfile) string {
+	sgr := style.sgr(profile)
+	if sgr == "" || text == "" {
+		return text
+	}
+	return restyle(text, sgr)
+}
+
+// sgr returns the SGR escape sequence setting the style, or "" for the default style.
+func (style Style) sgr(profile ColorProfile) string {
+	if profile == ColorProfileNoColor {
+		return ""
+	}
+	params := make([]string, 0, 6)
+	if style.Bold {
+		params = append(params, "1")
+	}
+	if style.Italic {
+		params = append(params, "3")
+	}
+	if style.Underline {
+		params = append(params, "4")
+	}
+	if style.Reverse {
+		params = append(params, "7")
+	}
+	if p := style.Foreground.sgrParams(profile, false); p != "" {
+		params = append(params, p)
+	}
+	if p := style.Background.sgrParams(profile, true); p != "" {
+		params = append(params, p)
+	}
+	if len(params) == 0 {
+		return ""
+	}
+	return "\x1b[" + strings.Join(params, ";") + "m"
+}
+
+// Styled returns a copy of the box pattern whose glyphs are rendered with a given style,
+// so that the borders of a Box can have their own colors while the content keeps its own styles.
+// - style is the style of the borders.
+// - profile is the color profile of the terminal.
+func (pattern BoxPattern) Styled(style Style, profile ColorProfile) BoxPattern {
+	return BoxPattern{
+		style.Render(pattern.TopLeftCorner, profile),
+		style.Render(pattern.TopBorder, profile),
+		style.Render(pattern.TopRightCorner, profile),
+		style.Render(pattern.LeftBorder, profile),
+		style.Render(pattern.RightBorder, profile),
+		style.Render(pattern.BottomLeftCorner, profile),
+		style.Render(pattern.BottomBorder, profile),
+		style.Render(pattern.BottomRightCorner, profile),
+	}
+}
+
+// Style renders each line of the Paragraph slice with a given style.
+// The style is restored after each reset found in the lines, full (ESC[0m) or partial (ESC[39m, ESC[22m...),
+// so the spans already styled keep their own colors.
+// - style is the style to apply.
+// - profile is the color profile of the terminal.
+func (linesIn Paragraph) Style(style Style, profile ColorProfile) (linesOut Paragraph) {
+	sgr := style.sgr(profile)
+	if sgr == "" {
+		return linesIn
+	}
+	l := len(linesIn)
+	linesOut = NewWithGivenLen(l)
+	for i := 0; i < l; i++ {
+		linesOut[i] = restyle(linesIn[i], sgr)
+	}
+	return
+}
+
+// restyle returns s set in the style of the SGR sequence sgr, which is restored after each reset found in s.
+// A reset is followed by the sequence setting the style merged with the attributes and colors set by s so far,
+// so that a partial reset of s, such as ESC[39m, brings back the color of the style instead of the default one.
+func restyle(s string, sgr string) string {
+	var outer, inner sgrState
+	outer.apply(sgr)
+	var sb strings.Builder
+	sb.Grow(len(s) + 2*len(sgr) + len(sgrReset))
+	sb.WriteString(sgr)
+	for s != "" {
+		i := strings.IndexByte(s, escapeChar)
+		if i < 0 {
+			sb.WriteString(s)
+			break
+		}
+		n := maxint(escapeLength(s[i:]), 1)
+		seq := s[i : i+n]
+		sb.WriteString(s[:i+n])
+		s = s[i+n:]
+		if isSGR(seq) && inner.apply(seq) {
+			sb.WriteString(outer.merge(inner).sequence())
+		}
+	}
+	sb.WriteString(sgrReset)
+	return sb.String()
+}
+
+// sgrParams returns the SGR parameters selecting the color as a foreground or a background color.
+func (c Color) sgrParams(profile ColorProfile, background bool) string {
+	c = c.degrade(profile)
+	switch c.mode {
+	case color16:
+		base := 30
+		if background {
+			base = 40
+		}
+		if c.value >= 8 {
+			return strconv.Itoa(base + 60 + int(c.value) - 8)
+		}
+		return strconv.Itoa(base + int(c.value))
+	case color256:
+		if background {
+			return "48;5;" + strconv.Itoa(int(c.value))
+		}
+		return "38;5;" + strconv.Itoa(int(c.value))
+	case colorRGB:
+		r, g, b := c.rgb()
+		prefix := "38;2;"
+		if background {
+			prefix = "48;2;"
+		}
+		return prefix + strconv.Itoa(int(r)) + ";" + strconv.Itoa(int(g)) + ";" + strconv.Itoa(int(b))
+	}
+	return ""
+}
+
+// degrade returns the nearest color supported by the profile.
+func (c Color) degrade(profile ColorProfile) Color {
+	switch {
+	case c.mode == colorDefault || profile == ColorProfileNoColor:
+		return Color{}
+	case c.mode == colorRGB && profile == ColorProfileANSI256:
+		return ANSI256Color(rgbTo256(c.rgb()))
+	case c.mode >= color256 && profile == ColorProfileANSI:
+		return ANSIColor(rgbTo16(c.rgb()))
+	}
+	return c
+}
+
+// rgb returns the red, green and blue components of the color.
+func (c Color) rgb() (r uint8, g uint8, b uint8) {
+	switch c.mode {
+	case color16:
+		p := ansi16Palette[c.value]
+		return p[0], p[1], p[2]
+	case color256:
+		switch {
+		case c.value < 16:
+			p := ansi16Palette[c.value]
+			return p[0], p[1], p[2]
+		case c.value < 232:
+			i := c.value - 16
+			return cubeLevels[i/36], cubeLevels[(i/6)%6], cubeLevels[i%6]
+		default:
+			v := uint8(8 + 10*(c.value-232))
+			return v, v, v
+		}
+	case colorRGB:
+		return uint8(c.value >> 16), uint8(c.value >> 8), uint8(c.value)
+	}
+	return 0, 0, 0
+}
+
+var cubeLevels = [6]uint8{0, 95, 135, 175, 215, 255}
+
+// ansi16Palette holds the RGB values of the 16 standard colors, as displayed by xterm.
+var ansi16Palette = [16][3]uint8{
+	{0, 0, 0}, {205, 0, 0}, {0, 205, 0}, {205, 205, 0},
+	{0, 0, 238}, {205, 0, 205}, {0, 205, 205}, {229, 229, 229},
+	{127, 127, 127}, {255, 0, 0}, {0, 255, 0}, {255, 255, 0},
+	{92, 92, 255}, {255, 0, 255}, {0, 255, 255}, {255, 255, 255},
+}
+
+// rgbTo256 returns the index of the nearest color of the xterm 256 colors palette.
+func rgbTo256(r uint8, g uint8, b uint8) uint8 {
+	if r == g && g == b {
+		switch {
+		case r < 8:
+			return 16
+		case r > 248:
+			return 231
+		}
+		return uint8(232 + (int(r)-8)*24/247)
+	}
+	level := func(v uint8) uint8 {
+		switch {
+		case v < 48:
+			return 0
+		case v < 115:
+			return 1
+		}
+		return (v - 35) / 40
+	}
+	return 16 + 36*level(r) + 6*level(g) + level(b)
+}
+
+// rgbTo16 returns the index of the nearest of the 16 standard colors.
+func rgbTo16(r uint8, g uint8, b uint8) (index uint8) {
+	best := -1
+	for i, p := range ansi16Palette {
+		dr, dg, db := int(r)-int(p[0]), int(g)-int(p[1]), int(b)-int(p[2])
+		d := dr*dr + dg*dg + db*db
+		if best < 0 || d < best {
+			best, index = d, uint8(i)
+		}
+	}
+	return
+}
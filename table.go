@@ -0,0 +1,184 @@
+package paragraph
+
+// TableCell is a cell of a Table. Its content can hold several lines.
+type TableCell struct {
+	Lines Paragraph
+	Span  int // number of columns covered by the cell, 0 and 1 for one
+}
+
+// Table is a grid of cells with an optional header row.
+// The rows can have different numbers of cells, the missing ones are rendered empty.
+type Table struct {
+	Header []TableCell
+	Rows   [][]TableCell
+}
+
+// TableSettings{Style: BoxStyleSingleLine, HeaderStyle: BoxStyleDoubleLine, MaxColumnWidth: 20, Align: []TextAlign{TextAlignLeft, TextAlignRight}}
+type TableSettings struct {
+	Style          BoxStyle    // BoxStyleNone is rendered like BoxStyleSpaceChar
+	HeaderStyle    BoxStyle    // BoxStyleNone for the style of the other rows
+	MaxColumnWidth int         // the cells are split with Limit beyond this width, 0 for no limit
+	Align          []TextAlign // alignment of each column, TextAlignLeft for the missing ones
+}
+
+// NewTableCell creates and returns a new TableCell from a string whose lines are separated by "\n".
+func NewTableCell(s string) TableCell {
+	return TableCell{Lines: NewFromString(s)}
+}
+
+// NewTableRow creates and returns a new row of cells, one for each string.
+func NewTableRow(cells ...string) (row []TableCell) {
+	row = make([]TableCell, len(cells))
+	for i, s := range cells {
+		row[i] = NewTableCell(s)
+	}
+	return
+}
+
+// Render returns the Table drawn with box-drawing characters.
+// The columns are as wide as their widest cells, and the cells spanning several columns widen them if required.
+// The borders of the adjacent cells are joined, so that the inner grid lines form junctions such as "┼",
+// and the header is separated from the other rows by a line of the header style.
+// - settings holds the styles, the maximum width of the columns and their alignments.
+func (table Table) Render(settings TableSettings) Paragraph {
+	rows := table.Rows
+	if table.Header != nil {
+		rows = append([][]TableCell{table.Header}, rows...)
+	}
+	columns := 0
+	for _, row := range rows {
+		n := 0
+		for _, c := range row {
+			n += c.span()
+		}
+		columns = maxint(columns, n)
+	}
+	if columns == 0 {
+		return Paragraph{}
+	}
+	// The cells of each row are completed, and their lines limited to the maximum width
+	cells := make([][]TableCell, len(rows))
+	for i, row := range rows {
+		n := 0
+		for _, c := range row {
+			lines := c.Lines
+			if settings.MaxColumnWidth > 0 {
+				lines = lines.Limit(settings.MaxColumnWidth)
+			}
+			cells[i] = append(cells[i], TableCell{lines, minint(c.span(), columns-n)})
+			n += c.span()
+			if n >= columns {
+				break
+			}
+		}
+		for ; n < columns; n++ {
+			cells[i] = append(cells[i], TableCell{})
+		}
+	}
+	widths := columnWidths(cells, columns)
+
+	style, headerStyle := settings.Style, settings.HeaderStyle
+	if style == BoxStyleNone {
+		style = BoxStyleSpaceChar
+	}
+	if headerStyle == BoxStyleNone {
+		headerStyle = style
+	}
+	// The cells are wrapped once, their line counts giving the heights of the rows
+	height := 1
+	heights := make([]int, len(cells))
+	contents := make([][]Paragraph, len(cells))
+	for i, row := range cells {
+		heights[i] = 1
+		contents[i] = make([]Paragraph, len(row))
+		for k, c := range row {
+			column := columnOf(row, k)
+			align := TextAlignLeft
+			if column < len(settings.Align) {
+				align = settings.Align[column]
+			}
+			contents[i][k] = c.Lines.Wrap(WrapSettings{Width: spannedWidth(widths, column, c.span()), Align: align})
+			heights[i] = maxint(heights[i], len(contents[i][k]))
+		}
+		height += heights[i] + 1
+	}
+	width := 1
+	for _, w := range widths {
+		width += w + 1
+	}
+	canvas := NewCanvas(width, height)
+	y := 0
+	for i, row := range cells {
+		pattern, z := GetBoxPattern(style), 0
+		if i == 0 && table.Header != nil {
+			pattern, z = GetBoxPattern(headerStyle), 1
+		}
+		x := 0
+		for k, c := range row {
+			column := columnOf(row, k)
+			w := spannedWidth(widths, column, c.span())
+			box := contents[i][k].BoxHeight(BoxSettings{Width: w}, HeightSettings{Height: heights[i]}, pattern)
+			canvas.Draw(box, DrawSettings{X: x, Y: y, Z: z, MergeLines: true})
+			x += w + 1
+		}
+		y += heights[i] + 1
+	}
+	return canvas.Paragraph()
+}
+
+// span returns the number of columns covered by the cell.
+func (c TableCell) span() int {
+	return maxint(c.Span, 1)
+}
+
+// columnOf returns the index of the first column covered by the k-th cell of a row.
+func columnOf(row []TableCell, k int) (column int) {
+	for _, c := range row[:k] {
+		column += c.span()
+	}
+	return
+}
+
+// spannedWidth returns the inner width of a cell covering span columns from the given one,
+// the borders between them included.
+func spannedWidth(widths []int, column int, span int) int {
+	width := span - 1
+	for _, w := range widths[column : column+span] {
+		width += w
+	}
+	return width
+}
+
+// columnWidths returns the widths of the columns, large enough for the cells covering a single column,
+// then widened from the left when the cells spanning several columns don't fit in them.
+func columnWidths(cells [][]TableCell, columns int) []int {
+	widths := make([]int, columns)
+	for i := range widths {
+		widths[i] = 1
+	}
+	for _, row := range cells {
+		for k, c := range row {
+			if c.span() == 1 {
+				column := columnOf(row, k)
+				widths[column] = maxint(widths[column], c.Lines.Width())
+			}
+		}
+	}
+	for _, row := range cells {
+		for k, c := range row {
+			column, span := columnOf(row, k), c.span()
+			missing := c.Lines.Width() - spannedWidth(widths, column, span)
+			for j := 0; missing > 0; j++ {
+				n := missing / span
+				if j < missing%span {
+					n++
+				}
+				widths[column+j] += n
+				if j == span-1 {
+					break
+				}
+			}
+		}
+	}
+	return widths
+}
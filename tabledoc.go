@@ -0,0 +1,188 @@
+package paragraph
+
+import (
+	"strconv"
+	"strings"
+)
+
+// Markdown returns the Table as a GitHub Flavored Markdown pipe table.
+// The pipes of the cells are escaped and their lines are joined with "<br>".
+// Markdown having no column spans, a cell spanning several columns is followed by empty cells,
+// and a header row of empty cells is added when the Table has none.
+// - settings holds the alignments of the columns, written with colons in the delimiter row.
+func (table Table) Markdown(settings TableSettings) (linesOut Paragraph) {
+	header, rows, columns := table.textCells(func(c TableCell) string {
+		return strings.ReplaceAll(strings.Join(c.Lines, "<br>"), "|", `\|`)
+	})
+	if columns == 0 {
+		return Paragraph{}
+	}
+	widths := make([]int, columns)
+	for _, row := range append([][]string{header}, rows...) {
+		for i, s := range row {
+			widths[i] = maxint(widths[i], DisplayWidth(s))
+		}
+	}
+	delimiters := make([]string, columns)
+	for i := range delimiters {
+		widths[i] = maxint(widths[i], 3)
+		align := TextAlignJustify // no colon
+		if i < len(settings.Align) {
+			align = settings.Align[i]
+		}
+		switch align {
+		case TextAlignLeft:
+			delimiters[i] = ":" + strings.Repeat("-", widths[i]-1)
+		case TextAlignCenter:
+			delimiters[i] = ":" + strings.Repeat("-", widths[i]-2) + ":"
+		case TextAlignRight:
+			delimiters[i] = strings.Repeat("-", widths[i]-1) + ":"
+		default:
+			delimiters[i] = strings.Repeat("-", widths[i])
+		}
+	}
+	line := func(row []string) string {
+		var sb strings.Builder
+		for i, s := range row {
+			sb.WriteString("| ")
+			sb.WriteString(padRight(s, " ", widths[i], DisplayWidth))
+			sb.WriteString(" ")
+		}
+		sb.WriteString("|")
+		return sb.String()
+	}
+	linesOut = New(len(rows) + 2)
+	linesOut = append(linesOut, line(header), line(delimiters))
+	for _, row := range rows {
+		linesOut = append(linesOut, line(row))
+	}
+	return
+}
+
+// RST returns the Table as a reStructuredText grid table, whose cells can hold several lines and span several columns.
+// The pipes of the cells are escaped, and the header row is separated from the others by a line of "=".
+// - settings holds the maximum width of the columns and their alignments.
+func (table Table) RST(settings TableSettings) Paragraph {
+	escape := func(row []TableCell) (escaped []TableCell) {
+		escaped = make([]TableCell, len(row))
+		for i, c := range row {
+			lines := c.Lines
+			if settings.MaxColumnWidth > 0 {
+				lines = lines.Limit(settings.MaxColumnWidth)
+			}
+			if len(lines) == 0 {
+				lines = Paragraph{""}
+			}
+			escaped[i] = TableCell{NewFromString(strings.ReplaceAll(strings.Join(lines, "\n"), "|", `\|`)).Surround(" ", " "), c.Span}
+		}
+		return
+	}
+	var padded Table
+	headerHeight := 0
+	if table.Header != nil {
+		padded.Header = escape(table.Header)
+		headerHeight = 1
+		for _, c := range padded.Header {
+			headerHeight = maxint(headerHeight, len(c.Lines))
+		}
+	}
+	padded.Rows = make([][]TableCell, len(table.Rows))
+	for i, row := range table.Rows {
+		padded.Rows[i] = escape(row)
+	}
+	linesOut := padded.Render(TableSettings{Style: BoxStyleAscii, Align: settings.Align})
+	if headerHeight > 0 && headerHeight+1 < len(linesOut) {
+		linesOut[headerHeight+1] = strings.ReplaceAll(linesOut[headerHeight+1], "-", "=")
+	}
+	return linesOut
+}
+
+// AsciiDoc returns the Table as an AsciiDoc table, whose cells can span several columns.
+// The pipes of the cells are escaped and their lines are joined with hard line breaks (" +").
+// The missing cells of the rows are written empty.
+// - settings holds the alignments of the columns, written in the cols attribute.
+func (table Table) AsciiDoc(settings TableSettings) (linesOut Paragraph) {
+	_, _, columns := table.textCells(func(TableCell) string { return "" })
+	if columns == 0 {
+		return Paragraph{}
+	}
+	specifiers := make([]string, columns)
+	for i := range specifiers {
+		specifiers[i] = "<"
+		if i < len(settings.Align) {
+			switch settings.Align[i] {
+			case TextAlignCenter:
+				specifiers[i] = "^"
+			case TextAlignRight:
+				specifiers[i] = ">"
+			}
+		}
+	}
+	attributes := `[cols="` + strings.Join(specifiers, ",") + `"`
+	if table.Header != nil {
+		attributes += `,options="header"`
+	}
+	linesOut = New(len(table.Rows) + 4)
+	linesOut = append(linesOut, attributes+"]", "|===")
+	line := func(row []TableCell) string {
+		var sb strings.Builder
+		n := 0
+		for _, c := range row {
+			if n > 0 {
+				sb.WriteString(" ")
+			}
+			n += c.span()
+			if c.span() > 1 {
+				sb.WriteString(strconv.Itoa(c.span()) + "+")
+			}
+			sb.WriteString("|")
+			sb.WriteString(strings.ReplaceAll(strings.Join(c.Lines, " +\n"), "|", `\|`))
+		}
+		for ; n < columns; n++ { // the cells flow into the rows, the missing ones must be written
+			if n > 0 {
+				sb.WriteString(" ")
+			}
+			sb.WriteString("|")
+		}
+		return sb.String()
+	}
+	if table.Header != nil {
+		linesOut = append(linesOut, NewFromString(line(table.Header))...)
+		linesOut = append(linesOut, "")
+	}
+	for _, row := range table.Rows {
+		linesOut = append(linesOut, NewFromString(line(row))...)
+	}
+	return append(linesOut, "|===")
+}
+
+// textCells returns the texts of the cells of the header and the rows, completed with empty cells
+// so that every row has the same number of columns, the cells spanning several columns being followed by empty cells.
+// - text returns the text of a cell.
+func (table Table) textCells(text func(TableCell) string) (header []string, rows [][]string, columns int) {
+	toTexts := func(row []TableCell) (texts []string) {
+		for _, c := range row {
+			texts = append(texts, text(c))
+			for k := 1; k < c.span(); k++ {
+				texts = append(texts, "")
+			}
+		}
+		return
+	}
+	header = toTexts(table.Header)
+	columns = len(header)
+	rows = make([][]string, len(table.Rows))
+	for i, row := range table.Rows {
+		rows[i] = toTexts(row)
+		columns = maxint(columns, len(rows[i]))
+	}
+	for len(header) < columns {
+		header = append(header, "")
+	}
+	for i := range rows {
+		for len(rows[i]) < columns {
+			rows[i] = append(rows[i], "")
+		}
+	}
+	return
+}
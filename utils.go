@@ -6,3 +6,10 @@ func maxint(x, y int) int {
 	}
 	return y
 }
+
+func minint(x, y int) int {
+	if x < y {
+		return x
+	}
+	return y
+}
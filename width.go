@@ -0,0 +1,245 @@
+package paragraph
+
+import (
+	"strings"
+	"unicode"
+
+	"github.com/tpfeiffer67/runesstr"
+)
+
+// WidthFunc returns the width of a piece of text.
+// It lets the caller choose how the Paragraph operations measure strings (rune count, byte count or terminal cells).
+type WidthFunc func(s string) int
+
+// RuneCount is a WidthFunc returning the number of runes in a string.
+func RuneCount(s string) int {
+	return runesstr.Length(s)
+}
+
+// ByteCount is a WidthFunc returning the number of bytes in a string.
+func ByteCount(s string) int {
+	return len(s)
+}
+
+// DisplayWidth is a WidthFunc returning the number of terminal cells needed to display a string.
+// East Asian Wide and Fullwidth characters and emoji presentation characters count as 2,
+// combining marks, zero-width joiners and other format or control characters count as 0,
+// and every other character counts as 1. The string is measured grapheme cluster by grapheme cluster,
+// so a flag or a ZWJ emoji sequence counts as a single wide character, and terminal escape sequences count as 0.
+func DisplayWidth(s string) (width int) {
+	for s != "" {
+		token, escape, rest := nextToken(s)
+		if !escape {
+			width += graphemeWidth(token)
+		}
+		s = rest
+	}
+	return
+}
+
+// runeWidth returns the number of terminal cells needed to display a single rune.
+func runeWidth(r rune) int {
+	switch {
+	case r < 0x20 || (r >= 0x7F && r < 0xA0):
+		return 0
+	case r < 0x300:
+		if r == 0xAD { // soft hyphen
+			return 0
+		}
+		return 1
+	case unicode.In(r, unicode.Mn, unicode.Me, unicode.Cf):
+		return 0
+	case inRanges(r, zeroWidthRanges):
+		return 0
+	case inRanges(r, wideRanges):
+		return 2
+	}
+	return 1
+}
+
+// measure returns the width of s using the WidthFunc f on each grapheme cluster of the string.
+// Terminal escape sequences are skipped.
+func measure(s string, f WidthFunc) (width int) {
+	for s != "" {
+		token, escape, rest := nextToken(s)
+		if !escape {
+			width += f(token)
+		}
+		s = rest
+	}
+	return
+}
+
+// cut returns the longest prefix of s whose width does not exceed maxWidth.
+// The style still active at the cut point is closed.
+func cut(s string, maxWidth int, f WidthFunc) string {
+	width := 0
+	for rest := s; rest != ""; {
+		token, escape, r := nextToken(rest)
+		if !escape {
+			width += f(token)
+		}
+		if width > maxWidth {
+			first, _ := carryStyle(s[:len(s)-len(rest)], "")
+			return first
+		}
+		rest = r
+	}
+	return s
+}
+
+// padRight pads s on the right side with fillPattern until its width reaches the given width.
+// If the fill pattern doesn't fit exactly, its first grapheme cluster(s) are repeated, and spaces are used
+// when a grapheme cluster of the fill pattern is wider than the remaining room.
+func padRight(s string, fillPattern string, width int, f WidthFunc) string {
+	if measure(fillPattern, f) == 0 {
+		return s
+	}
+	current := measure(s, f)
+	if current >= width {
+		return s
+	}
+	var sb strings.Builder
+	sb.WriteString(s)
+	for current < width {
+		for p := fillPattern; p != "" && current < width; {
+			token, escape, rest := nextToken(p)
+			p = rest
+			w := 0
+			if !escape {
+				w = f(token)
+			}
+			if current+w > width {
+				sb.WriteString(strings.Repeat(" ", width-current))
+				current = width
+				break
+			}
+			sb.WriteString(token)
+			current += w
+		}
+	}
+	padded := sb.String()
+	if activeSGR(s) == "" && activeSGR(padded) != "" { // the fill pattern style must not bleed
+		padded += sgrReset
+	}
+	return padded
+}
+
+// splitOnNearestSpace splits s into two parts at the nearest space before the given width.
+// It behaves like runesstr.SplitOnNearestSpace, but the positions are computed with the WidthFunc f
+// on grapheme clusters, and terminal escape sequences are never split.
+func splitOnNearestSpace(s string, max int, f WidthFunc) (first string, second string) {
+	trimmed := strings.TrimSpace(s)
+	if measure(trimmed, f) <= max {
+		return trimmed, ""
+	}
+	// offsets holds the byte offset of each token, n the number of tokens fitting in max,
+	// and minimum the number of tokens up to the first grapheme cluster
+	offsets := make([]int, 0, len(trimmed))
+	n, minimum, width := -1, 0, 0
+	for rest := trimmed; rest != ""; {
+		offsets = append(offsets, len(trimmed)-len(rest))
+		token, escape, r := nextToken(rest)
+		if !escape {
+			width += f(token)
+			if minimum == 0 {
+				minimum = len(offsets)
+			}
+		}
+		if n < 0 && width > max {
+			n = len(offsets) - 1
+		}
+		rest = r
+	}
+	for i := n; i >= 1; i-- {
+		if trimmed[offsets[i]] == ' ' && trimmed[offsets[i-1]] != ' ' {
+			first = trimmed[:offsets[i]]
+			second = strings.TrimSpace(trimmed[offsets[i]:])
+			return
+		}
+	}
+	// No space found, the word is truncated but at least one grapheme cluster is kept,
+	// even if it is wider than max
+	if n < minimum {
+		n = minimum
+	}
+	if n >= len(offsets) || measure(trimmed[offsets[n]:], f) == 0 {
+		return trimmed, ""
+	}
+	first = trimmed[:offsets[n]]
+	second = strings.TrimSpace(trimmed[offsets[n]:])
+	return
+}
+
+type runeRange struct {
+	first rune
+	last  rune
+}
+
+// inRanges reports whether r belongs to one of the sorted ranges.
+func inRanges(r rune, ranges []runeRange) bool {
+	lo, hi := 0, len(ranges)
+	for lo < hi {
+		m := lo + (hi-lo)/2
+		switch {
+		case r < ranges[m].first:
+			hi = m
+		case r > ranges[m].last:
+			lo = m + 1
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// zeroWidthRanges lists the characters that are not in the Mn, Me or Cf categories but have no width,
+// such as the Hangul medial vowels and final consonants.
+var zeroWidthRanges = []runeRange{
+	{0x1160, 0x11FF},
+	{0xD7B0, 0xD7FF},
+}
+
+// wideRanges lists the East Asian Wide (W) and Fullwidth (F) characters, emoji presentation included.
+var wideRanges = []runeRange{
+	{0x1100, 0x115F}, {0x231A, 0x231B}, {0x2329, 0x232A}, {0x23E9, 0x23EC},
+	{0x23F0, 0x23F0}, {0x23F3, 0x23F3}, {0x25FD, 0x25FE}, {0x2614, 0x2615},
+	{0x2648, 0x2653}, {0x267F, 0x267F}, {0x2693, 0x2693}, {0x26A1, 0x26A1},
+	{0x26AA, 0x26AB}, {0x26BD, 0x26BE}, {0x26C4, 0x26C5}, {0x26CE, 0x26CE},
+	{0x26D4, 0x26D4}, {0x26EA, 0x26EA}, {0x26F2, 0x26F3}, {0x26F5, 0x26F5},
+	{0x26FA, 0x26FA}, {0x26FD, 0x26FD}, {0x2705, 0x2705}, {0x270A, 0x270B},
+	{0x2728, 0x2728}, {0x274C, 0x274C}, {0x274E, 0x274E}, {0x2753, 0x2755},
+	{0x2757, 0x2757}, {0x2795, 0x2797}, {0x27B0, 0x27B0}, {0x27BF, 0x27BF},
+	{0x2B1B, 0x2B1C}, {0x2B50, 0x2B50}, {0x2B55, 0x2B55}, {0x2E80, 0x2E99},
+	{0x2E9B, 0x2EF3}, {0x2F00, 0x2FD5}, {0x2FF0, 0x2FFB}, {0x3000, 0x303E},
+	{0x3041, 0x3096}, {0x3099, 0x30FF}, {0x3105, 0x312F}, {0x3131, 0x318E},
+	{0x3190, 0x31E3}, {0x31F0, 0x321E}, {0x3220, 0x3247}, {0x3250, 0x4DBF},
+	{0x4E00, 0xA48C}, {0xA490, 0xA4C6}, {0xA960, 0xA97C}, {0xAC00, 0xD7A3},
+	{0xF900, 0xFAFF}, {0xFE10, 0xFE19}, {0xFE30, 0xFE52}, {0xFE54, 0xFE66},
+	{0xFE68, 0xFE6B}, {0xFF01, 0xFF60}, {0xFFE0, 0xFFE6}, {0x16FE0, 0x16FE4},
+	{0x16FF0, 0x16FF1}, {0x17000, 0x187F7}, {0x18800, 0x18CD5}, {0x18D00, 0x18D08},
+	{0x1AFF0, 0x1AFF3}, {0x1AFF5, 0x1AFFB}, {0x1AFFD, 0x1AFFE}, {0x1B000, 0x1B122},
+	{0x1B132, 0x1B132}, {0x1B150, 0x1B152}, {0x1B155, 0x1B155}, {0x1B164, 0x1B167},
+	{0x1B170, 0x1B2FB}, {0x1F004, 0x1F004}, {0x1F0CF, 0x1F0CF}, {0x1F18E, 0x1F18E},
+	{0x1F191, 0x1F19A}, {0x1F200, 0x1F202}, {0x1F210, 0x1F23B}, {0x1F240, 0x1F248},
+	{0x1F250, 0x1F251}, {0x1F260, 0x1F265}, {0x1F300, 0x1F320}, {0x1F32D, 0x1F335},
+	{0x1F337, 0x1F37C}, {0x1F37E, 0x1F393}, {0x1F3A0, 0x1F3CA}, {0x1F3CF, 0x1F3D3},
+	{0x1F3E0, 0x1F3F0}, {0x1F3F4, 0x1F3F4}, {0x1F3F8, 0x1F43E}, {0x1F440, 0x1F440},
+	{0x1F442, 0x1F4FC}, {0x1F4FF, 0x1F53D}, {0x1F54B, 0x1F54E}, {0x1F550, 0x1F567},
+	{0x1F57A, 0x1F57A}, {0x1F595, 0x1F596}, {0x1F5A4, 0x1F5A4}, {0x1F5FB, 0x1F64F},
+	{0x1F680, 0x1F6C5}, {0x1F6CC, 0x1F6CC}, {0x1F6D0, 0x1F6D2}, {0x1F6D5, 0x1F6D7},
+	{0x1F6DC, 0x1F6DF}, {0x1F6EB, 0x1F6EC}, {0x1F6F4, 0x1F6FC}, {0x1F7E0, 0x1F7EB},
+	{0x1F7F0, 0x1F7F0}, {0x1F90C, 0x1F93A}, {0x1F93C, 0x1F945}, {0x1F947, 0x1F9FF},
+	{0x1FA70, 0x1FA7C}, {0x1FA80, 0x1FA88}, {0x1FA90, 0x1FABD}, {0x1FABF, 0x1FAC5},
+	{0x1FACE, 0x1FADB}, {0x1FAE0, 0x1FAE8}, {0x1FAF0, 0x1FAF8}, {0x20000, 0x2FFFD},
+	{0x30000, 0x3FFFD},
+}
+
+// fill returns the fill pattern repeated to a given width, or "" if the width is not positive.
+// The style of the fill pattern is closed, so that it doesn't bleed on what follows.
+func fill(fillPattern string, width int, f WidthFunc) string {
+	if width <= 0 {
+		return ""
+	}
+	return padRight("", fillPattern, width, f)
+}
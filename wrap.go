@@ -0,0 +1,126 @@
+package paragraph
+
+import (
+	"strings"
+)
+
+// WrapSettings{Width: 30, Align: TextAlignJustify, Strategy: WrapStrategyOptimal}
+type WrapSettings struct {
+	Width      int
+	Align      TextAlign
+	Strategy   WrapStrategy
+	Penalties  *BreakPenalties // nil means DefaultBreakPenalties, &BreakPenalties{} turns them all off
+	Hyphenator *Hyphenator     // nil means no hyphenation
+}
+
+// Wrap splits the strings of the Paragraph slice that exceed a given width, like Limit does,
+// and aligns the resulting lines within this width.
+// Every line is padded with spaces to the width. When justified, the extra spaces are distributed
+// between the words, and the last line of each string stays left-aligned.
+// The greedy strategy gives the same lines as Limit, the optimal strategy chooses the breaks
+// minimizing the raggedness of the whole string (see WrapStrategyOptimal).
+// The words can also be broken at their soft hyphens (U+00AD) and, when a Hyphenator is set,
+// at their hyphenation points. A hyphen is then added at the end of the line,
+// and the soft hyphens which are not used are removed.
+// - settings holds the width, the alignment and the line breaking strategy.
+func (linesIn Paragraph) Wrap(settings WrapSettings) (linesOut Paragraph) {
+	if settings.Width < 1 || settings.Width > MultiStringsMaxWidth {
+		return linesIn
+	}
+	linesOut = New(len(linesIn))
+	for _, s := range linesIn {
+		linesOut = appendWrapped(linesOut, s, settings, settings.Width)
+	}
+	return
+}
+
+// appendWrapped appends to linesOut the lines obtained by wrapping s and aligning it within the width.
+// - firstWidth is the width of the first line, which can differ from the width of the next ones.
+func appendWrapped(linesOut Paragraph, s string, settings WrapSettings, firstWidth int) Paragraph {
+	first := len(linesOut)
+	penalties := DefaultBreakPenalties
+	if settings.Penalties != nil {
+		penalties = *settings.Penalties
+	}
+	switch {
+	case settings.Strategy == WrapStrategyOptimal:
+		linesOut = appendOptimal(linesOut, s, firstWidth, settings.Width, penalties, settings.Hyphenator)
+	case settings.Hyphenator != nil || strings.Contains(s, softHyphen):
+		linesOut = appendGreedy(linesOut, s, firstWidth, settings.Width, penalties, settings.Hyphenator)
+	default:
+		linesOut = appendLimited(linesOut, s, firstWidth, settings.Width, DisplayWidth)
+	}
+	for i := first; i < len(linesOut); i++ {
+		width := settings.Width
+		if i == first {
+			width = firstWidth
+		}
+		linesOut[i] = alignLine(linesOut[i], width, settings.Align, i == len(linesOut)-1)
+	}
+	return linesOut
+}
+
+// appendLimited appends to linesOut the lines obtained by splitting s on the nearest spaces,
+// so that none of them exceeds maxWidth, or firstWidth for the first line.
+func appendLimited(linesOut Paragraph, s string, firstWidth int, maxWidth int, f WidthFunc) Paragraph {
+	for width := firstWidth; ; width = maxWidth {
+		var sl string
+		if measure(s, f) > width {
+			sl, s = carryStyle(splitOnNearestSpace(s, width, f))
+			linesOut = append(linesOut, sl)
+			if s == "" { // a grapheme cluster wider than the width, or trailing spaces
+				break
+			}
+		} else {
+			linesOut = append(linesOut, s)
+			break
+		}
+	}
+	return linesOut
+}
+
+// alignLine aligns a line within a given width.
+// - last tells whether the line is the last one of a paragraph, which is never justified.
+func alignLine(s string, width int, align TextAlign, last bool) string {
+	l := width - DisplayWidth(s)
+	if l <= 0 {
+		return s
+	}
+	switch align {
+	case TextAlignRight:
+		return strings.Repeat(" ", l) + s
+	case TextAlignCenter:
+		return strings.Repeat(" ", l/2+l%2) + s + strings.Repeat(" ", l/2)
+	case TextAlignJustify:
+		if !last {
+			return justifyLine(s, width)
+		}
+	}
+	return s + strings.Repeat(" ", l)
+}
+
+// justifyLine distributes spaces between the words of a line so that it fills the given width.
+// The leftmost gaps receive the remaining spaces when they can't be evenly distributed.
+func justifyLine(s string, width int) string {
+	words := strings.FieldsFunc(s, func(r rune) bool { return r == ' ' }) // no-break spaces are kept
+	if len(words) < 2 {
+		return s + strings.Repeat(" ", width-DisplayWidth(s))
+	}
+	l := width
+	for _, w := range words {
+		l -= DisplayWidth(w)
+	}
+	gaps := len(words) - 1
+	var sb strings.Builder
+	for i, w := range words {
+		sb.WriteString(w)
+		if i < gaps {
+			n := l / gaps
+			if i < l%gaps {
+				n++
+			}
+			sb.WriteString(strings.Repeat(" ", n))
+		}
+	}
+	return sb.String()
+}